@@ -0,0 +1,181 @@
+package linq
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// SumBig computes the sum of a collection of arbitrary-precision numbers.
+//
+// Values can be of type *big.Int, *big.Float or *big.Rat, and all of them have
+// to be of the same type. The result is a newly allocated value of that type,
+// the elements of the collection are never modified. A *big.Float result has
+// the largest precision of the summed values. Method returns nil if the
+// collection contains no elements.
+func (q Query) SumBig() any {
+	return q.SumBigBy(identity)
+}
+
+// SumBigBy computes the sum of the arbitrary-precision numbers obtained by
+// invoking a transform function on each element of a collection.
+func (q Query) SumBigBy(selector func(any) any) any {
+	r, _ := q.foldBig("SumBig", selector, false)
+	return r
+}
+
+// SumBigByT is the typed version of SumBigBy.
+//
+//   - selectorFn is of type "func(TSource) TBig"
+//
+// NOTE: SumBigBy has better performance than SumBigByT.
+func (q Query) SumBigByT(selectorFn any) any {
+	selectorGenericFunc, err := newGenericFunc(
+		"SumBigByT", "selectorFn", selectorFn,
+		simpleParamValidator(newElemTypeSlice(new(genericType)), newElemTypeSlice(new(genericType))),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	selectorFunc := func(item any) any {
+		return selectorGenericFunc.Call(item)
+	}
+
+	return q.SumBigBy(selectorFunc)
+}
+
+// ProductBig computes the product of a collection of arbitrary-precision
+// numbers.
+//
+// Values can be of type *big.Int, *big.Float or *big.Rat, and all of them have
+// to be of the same type. The result is a newly allocated value of that type,
+// the elements of the collection are never modified. Method returns nil if the
+// collection contains no elements.
+func (q Query) ProductBig() any {
+	return q.ProductBigBy(identity)
+}
+
+// ProductBigBy computes the product of the arbitrary-precision numbers
+// obtained by invoking a transform function on each element of a collection.
+func (q Query) ProductBigBy(selector func(any) any) any {
+	r, _ := q.foldBig("ProductBig", selector, true)
+	return r
+}
+
+// ProductBigByT is the typed version of ProductBigBy.
+//
+//   - selectorFn is of type "func(TSource) TBig"
+//
+// NOTE: ProductBigBy has better performance than ProductBigByT.
+func (q Query) ProductBigByT(selectorFn any) any {
+	selectorGenericFunc, err := newGenericFunc(
+		"ProductBigByT", "selectorFn", selectorFn,
+		simpleParamValidator(newElemTypeSlice(new(genericType)), newElemTypeSlice(new(genericType))),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	selectorFunc := func(item any) any {
+		return selectorGenericFunc.Call(item)
+	}
+
+	return q.ProductBigBy(selectorFunc)
+}
+
+// AverageBig computes the exact average of a collection of arbitrary-precision
+// numbers.
+//
+// Values can be of type *big.Int, *big.Float or *big.Rat, and all of them have
+// to be of the same type. The average of *big.Int and *big.Rat values is a
+// *big.Rat, and the average of *big.Float values is a *big.Float. Method
+// returns nil if the collection contains no elements.
+func (q Query) AverageBig() any {
+	return q.AverageBigBy(identity)
+}
+
+// AverageBigBy computes the exact average of the arbitrary-precision numbers
+// obtained by invoking a transform function on each element of a collection.
+func (q Query) AverageBigBy(selector func(any) any) any {
+	sum, n := q.foldBig("AverageBig", selector, false)
+
+	switch s := sum.(type) {
+	case *big.Int:
+		return new(big.Rat).SetFrac(s, big.NewInt(n))
+	case *big.Rat:
+		return s.Quo(s, new(big.Rat).SetInt64(n))
+	case *big.Float:
+		return s.Quo(s, new(big.Float).SetInt64(n))
+	default:
+		return nil
+	}
+}
+
+// AverageBigByT is the typed version of AverageBigBy.
+//
+//   - selectorFn is of type "func(TSource) TBig"
+//
+// NOTE: AverageBigBy has better performance than AverageBigByT.
+func (q Query) AverageBigByT(selectorFn any) any {
+	selectorGenericFunc, err := newGenericFunc(
+		"AverageBigByT", "selectorFn", selectorFn,
+		simpleParamValidator(newElemTypeSlice(new(genericType)), newElemTypeSlice(new(genericType))),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	selectorFunc := func(item any) any {
+		return selectorGenericFunc.Call(item)
+	}
+
+	return q.AverageBigBy(selectorFunc)
+}
+
+// foldBig sums or multiplies the arbitrary-precision numbers obtained by
+// invoking selector on each element of the collection into a newly allocated
+// value, and returns it along with the number of elements.
+func (q Query) foldBig(methodName string, selector func(any) any,
+	product bool) (r any, n int64) {
+	for item := range q.Iterate {
+		value := selector(item)
+		n++
+
+		switch acc := r.(type) {
+		case nil:
+			switch v := value.(type) {
+			case *big.Int:
+				r = new(big.Int).Set(v)
+			case *big.Float:
+				r = new(big.Float).Set(v)
+			case *big.Rat:
+				r = new(big.Rat).Set(v)
+			default:
+				panic(fmt.Sprintf("%s: unsupported type %T", methodName, value))
+			}
+		case *big.Int:
+			if product {
+				acc.Mul(acc, value.(*big.Int))
+			} else {
+				acc.Add(acc, value.(*big.Int))
+			}
+		case *big.Float:
+			if v := value.(*big.Float); v.Prec() > acc.Prec() {
+				acc.SetPrec(v.Prec())
+			}
+			if product {
+				acc.Mul(acc, value.(*big.Float))
+			} else {
+				acc.Add(acc, value.(*big.Float))
+			}
+		case *big.Rat:
+			if product {
+				acc.Mul(acc, value.(*big.Rat))
+			} else {
+				acc.Add(acc, value.(*big.Rat))
+			}
+		}
+	}
+
+	return
+}
@@ -0,0 +1,135 @@
+package linq
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+func bigInts(values ...string) []*big.Int {
+	r := make([]*big.Int, len(values))
+	for i, v := range values {
+		r[i], _ = new(big.Int).SetString(v, 10)
+	}
+	return r
+}
+
+func TestSumBig(t *testing.T) {
+	input := bigInts("9223372036854775807", "9223372036854775807", "2")
+	want, _ := new(big.Int).SetString("18446744073709551616", 10)
+
+	r := FromSlice(input).SumBig()
+	if r.(*big.Int).Cmp(want) != 0 {
+		t.Errorf("From(%v).SumBig()=%v expected %v", input, r, want)
+	}
+	if input[0].String() != "9223372036854775807" {
+		t.Errorf("From(%v).SumBig() modified its input", input)
+	}
+
+	rats := []*big.Rat{big.NewRat(1, 3), big.NewRat(1, 6)}
+	if r := FromSlice(rats).SumBig(); r.(*big.Rat).Cmp(big.NewRat(1, 2)) != 0 {
+		t.Errorf("From(%v).SumBig()=%v expected 1/2", rats, r)
+	}
+
+	floats := []*big.Float{big.NewFloat(0.5), new(big.Float).SetPrec(200).SetFloat64(0.25)}
+	r = FromSlice(floats).SumBig()
+	if r.(*big.Float).Cmp(big.NewFloat(0.75)) != 0 || r.(*big.Float).Prec() != 200 {
+		t.Errorf("From(%v).SumBig()=%v (precision %d) expected 0.75 (precision 200)", floats, r, r.(*big.Float).Prec())
+	}
+
+	if r := From([]*big.Int{}).SumBig(); r != nil {
+		t.Errorf("From([]).SumBig()=%v expected nil", r)
+	}
+}
+
+func TestSumBigBy(t *testing.T) {
+	type entry struct {
+		amount *big.Rat
+	}
+	input := []entry{{big.NewRat(1, 10)}, {big.NewRat(2, 10)}}
+
+	r := FromSlice(input).SumBigBy(func(i any) any { return i.(entry).amount })
+	if r.(*big.Rat).Cmp(big.NewRat(3, 10)) != 0 {
+		t.Errorf("From(%v).SumBigBy()=%v expected 3/10", input, r)
+	}
+
+	r = FromSlice(input).SumBigByT(func(e entry) *big.Rat { return e.amount })
+	if r.(*big.Rat).Cmp(big.NewRat(3, 10)) != 0 {
+		t.Errorf("From(%v).SumBigByT()=%v expected 3/10", input, r)
+	}
+}
+
+func TestSumBig_PanicWhenTypeIsUnsupported(t *testing.T) {
+	mustPanicWithError(t, "SumBig: unsupported type int", func() {
+		From([]int{1, 2}).SumBig()
+	})
+}
+
+func TestSumBigByT_PanicWhenSelectorFnIsInvalid(t *testing.T) {
+	mustPanicWithError(t, "SumBigByT: parameter [selectorFn] has a invalid function signature. Expected: 'func(T)T', actual: 'func(int,int)int'", func() {
+		From([]int{1, 2}).SumBigByT(func(i, j int) int { return i })
+	})
+}
+
+func TestProductBig(t *testing.T) {
+	input := bigInts("4294967296", "4294967296", "3")
+	want, _ := new(big.Int).SetString("55340232221128654848", 10)
+
+	if r := FromSlice(input).ProductBig(); r.(*big.Int).Cmp(want) != 0 {
+		t.Errorf("From(%v).ProductBig()=%v expected %v", input, r, want)
+	}
+
+	rats := []*big.Rat{big.NewRat(2, 3), big.NewRat(3, 4)}
+	if r := FromSlice(rats).ProductBigBy(identity); r.(*big.Rat).Cmp(big.NewRat(1, 2)) != 0 {
+		t.Errorf("From(%v).ProductBigBy()=%v expected 1/2", rats, r)
+	}
+}
+
+func TestProductBigByT_PanicWhenSelectorFnIsInvalid(t *testing.T) {
+	mustPanicWithError(t, "ProductBigByT: parameter [selectorFn] has a invalid function signature. Expected: 'func(T)T', actual: 'func(int,int)int'", func() {
+		From([]int{1, 2}).ProductBigByT(func(i, j int) int { return i })
+	})
+}
+
+func TestAverageBig(t *testing.T) {
+	tests := []struct {
+		input any
+		want  string
+	}{
+		{bigInts("1", "2"), "3/2"},
+		{[]*big.Rat{big.NewRat(1, 2), big.NewRat(1, 4)}, "3/8"},
+		{[]*big.Float{big.NewFloat(1), big.NewFloat(2)}, "1.5"},
+	}
+
+	for _, test := range tests {
+		if r := From(test.input).AverageBig(); r.(fmt.Stringer).String() != test.want {
+			t.Errorf("From(%v).AverageBig()=%v expected %v", test.input, r, test.want)
+		}
+	}
+
+	if r := From([]*big.Rat{}).AverageBig(); r != nil {
+		t.Errorf("From([]).AverageBig()=%v expected nil", r)
+	}
+}
+
+func TestAverageBigByT_PanicWhenSelectorFnIsInvalid(t *testing.T) {
+	mustPanicWithError(t, "AverageBigByT: parameter [selectorFn] has a invalid function signature. Expected: 'func(T)T', actual: 'func(int,int)int'", func() {
+		From([]int{1, 2}).AverageBigByT(func(i, j int) int { return i })
+	})
+}
+
+func TestBig_MinMaxOrderBy(t *testing.T) {
+	input := bigInts("30000000000000000000", "-5", "20000000000000000000")
+
+	if r := FromSlice(input).Max(); r != input[0] {
+		t.Errorf("From(%v).Max()=%v expected %v", input, r, input[0])
+	}
+	if r := FromSlice(input).Min(); r != input[1] {
+		t.Errorf("From(%v).Min()=%v expected %v", input, r, input[1])
+	}
+
+	want := []any{input[1], input[2], input[0]}
+	if q := FromSlice(input).OrderBy(identity); !testQueryIteration(q.Query, want) {
+		t.Errorf("From(%v).OrderBy()=%v expected %v", input, toSlice(q.Query), want)
+	}
+}
@@ -1,5 +1,7 @@
 package linq
 
+import "math/big"
+
 type comparer func(any, any) int
 
 // Comparable is an interface that has to be implemented by a custom type
@@ -192,6 +194,18 @@ func getComparer(data any) comparer {
 				return -1
 			}
 		}
+	case *big.Int:
+		return func(x, y any) int {
+			return x.(*big.Int).Cmp(y.(*big.Int))
+		}
+	case *big.Float:
+		return func(x, y any) int {
+			return x.(*big.Float).Cmp(y.(*big.Float))
+		}
+	case *big.Rat:
+		return func(x, y any) int {
+			return x.(*big.Rat).Cmp(y.(*big.Rat))
+		}
 	default:
 		return func(x, y any) int {
 			a, b := x.(Comparable), y.(Comparable)
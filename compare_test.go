@@ -1,6 +1,9 @@
 package linq
 
-import "testing"
+import (
+	"math/big"
+	"testing"
+)
 
 func TestGetComparer(t *testing.T) {
 	tests := []struct {
@@ -55,6 +58,11 @@ func TestGetComparer(t *testing.T) {
 		{foo{f1: 1}, foo{f1: 5}, -1},
 		{foo{f1: 5}, foo{f1: 1}, 1},
 		{foo{f1: 1}, foo{f1: 1}, 0},
+		{big.NewInt(1), big.NewInt(5), -1},
+		{big.NewInt(5), big.NewInt(5), 0},
+		{big.NewFloat(5.5), big.NewFloat(1), 1},
+		{big.NewRat(1, 3), big.NewRat(1, 2), -1},
+		{big.NewRat(2, 4), big.NewRat(1, 2), 0},
 	}
 
 	for _, test := range tests {
@@ -26,7 +26,8 @@ func (q Query) Append(item any) Query {
 //
 // The Concat method differs from the Union method because the Concat method
 // returns all the original elements in the input sequences. The Union method
-// returns only unique elements.
+// returns only unique elements. Concat is therefore the equivalent of SQL's
+// UNION ALL, which complements ExceptAll and IntersectAll.
 func (q Query) Concat(q2 Query) Query {
 	return Query{
 		Iterate: func(yield func(any) bool) {
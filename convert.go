@@ -68,3 +68,22 @@ func getFloatConverter(data any) floatConverter {
 		return i.(float64)
 	}
 }
+
+// getNumberConverter returns a converter of any integer, unsigned integer or
+// float type to float64.
+func getNumberConverter(data any) floatConverter {
+	switch data.(type) {
+	case int, int8, int16, int32, int64:
+		conv := getIntConverter(data)
+		return func(i any) float64 {
+			return float64(conv(i))
+		}
+	case uint, uint8, uint16, uint32, uint64:
+		conv := getUIntConverter(data)
+		return func(i any) float64 {
+			return float64(conv(i))
+		}
+	}
+
+	return getFloatConverter(data)
+}
@@ -55,3 +55,24 @@ func TestFloatConverter(t *testing.T) {
 		}
 	}
 }
+
+func TestNumberConverter(t *testing.T) {
+	tests := []struct {
+		input any
+		want  float64
+	}{
+		{-2, -2},
+		{int8(-1), -1},
+		{int64(5), 5},
+		{uint(2), 2},
+		{uint64(7), 7},
+		{float32(-1.5), -1.5},
+		{float64(0.25), 0.25},
+	}
+
+	for _, test := range tests {
+		if conv := getNumberConverter(test.input); conv(test.input) != test.want {
+			t.Errorf("NumberConverter for %v failed", test.input)
+		}
+	}
+}
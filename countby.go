@@ -0,0 +1,111 @@
+package linq
+
+// CountBy counts the elements of a collection for each key returned by a
+// specified key selector function. It yields a KeyValue for every distinct
+// key, with the key as Key and the number of elements as an int Value, in the
+// order the keys are first seen.
+//
+// Unlike GroupBy, CountBy doesn't store the elements of the groups, only one
+// counter per key.
+func (q Query) CountBy(keySelector func(any) any) Query {
+	return q.AggregateBy(keySelector, 0, func(count, item any) any {
+		return count.(int) + 1
+	})
+}
+
+// CountByT is the typed version of CountBy.
+//
+//   - keySelectorFn is of type "func(TSource) TKey"
+//
+// NOTE: CountBy has better performance than CountByT.
+func (q Query) CountByT(keySelectorFn any) Query {
+	keySelectorGenericFunc, err := newGenericFunc(
+		"CountByT", "keySelectorFn", keySelectorFn,
+		simpleParamValidator(newElemTypeSlice(new(genericType)), newElemTypeSlice(new(genericType))),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	keySelectorFunc := func(item any) any {
+		return keySelectorGenericFunc.Call(item)
+	}
+
+	return q.CountBy(keySelectorFunc)
+}
+
+// AggregateBy applies an accumulator function over the elements of a
+// collection that share the same key, as returned by a specified key selector
+// function. It yields a KeyValue for every distinct key, with the key as Key
+// and the final accumulated value as Value, in the order the keys are first
+// seen.
+//
+// The seed value is used as the initial accumulator value of every key. Like
+// AggregateWithSeed, f is called once for each element, with the accumulated
+// value of the element's key as the first argument, and its result replaces
+// that accumulated value. Unlike GroupBy followed by an aggregation, the
+// elements of the groups are never stored.
+//
+// If seed is a reference type, such as a slice or a map, it is shared by all
+// the keys, so f should not modify it in place.
+func (q Query) AggregateBy(keySelector func(any) any, seed any,
+	f func(accumulator, item any) any) Query {
+	return Query{
+		Iterate: func(yield func(any) bool) {
+			index := make(map[any]int)
+			var results []KeyValue
+
+			for item := range q.Iterate {
+				key := keySelector(item)
+				i, seen := index[key]
+				if !seen {
+					i = len(results)
+					index[key] = i
+					results = append(results, KeyValue{Key: key, Value: seed})
+				}
+
+				results[i].Value = f(results[i].Value, item)
+			}
+
+			for _, result := range results {
+				if !yield(result) {
+					return
+				}
+			}
+		},
+	}
+}
+
+// AggregateByT is the typed version of AggregateBy.
+//
+//   - keySelectorFn is of type "func(TSource) TKey"
+//   - f is of type "func(TAccumulate, TSource) TAccumulate"
+//
+// NOTE: AggregateBy has better performance than AggregateByT.
+func (q Query) AggregateByT(keySelectorFn any, seed any, f any) Query {
+	keySelectorGenericFunc, err := newGenericFunc(
+		"AggregateByT", "keySelectorFn", keySelectorFn,
+		simpleParamValidator(newElemTypeSlice(new(genericType)), newElemTypeSlice(new(genericType))),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	keySelectorFunc := func(item any) any {
+		return keySelectorGenericFunc.Call(item)
+	}
+
+	fGenericFunc, err := newGenericFunc(
+		"AggregateByT", "f", f,
+		simpleParamValidator(newElemTypeSlice(new(genericType), new(genericType)), newElemTypeSlice(new(genericType))),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	fFunc := func(accumulator any, item any) any {
+		return fGenericFunc.Call(accumulator, item)
+	}
+
+	return q.AggregateBy(keySelectorFunc, seed, fFunc)
+}
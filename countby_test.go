@@ -0,0 +1,58 @@
+package linq
+
+import "testing"
+
+func TestCountBy(t *testing.T) {
+	input := []string{"apple", "kiwi", "banana", "pear", "fig", "plum"}
+	want := []any{KeyValue{5, 1}, KeyValue{4, 3}, KeyValue{6, 1}, KeyValue{3, 1}}
+
+	if q := From(input).CountBy(func(i any) any {
+		return len(i.(string))
+	}); !testQueryIteration(q, want) {
+		t.Errorf("From(%v).CountBy()=%v expected %v", input, toSlice(q), want)
+	}
+}
+
+func TestCountByT_PanicWhenKeySelectorFnIsInvalid(t *testing.T) {
+	mustPanicWithError(t, "CountByT: parameter [keySelectorFn] has a invalid function signature. Expected: 'func(T)T', actual: 'func(int,int)int'", func() {
+		From([]int{1, 2}).CountByT(func(i, j int) int { return i })
+	})
+}
+
+func TestAggregateBy(t *testing.T) {
+	input := []int{1, 2, 3, 4, 5, 6, 7}
+	want := []any{KeyValue{1, 16}, KeyValue{0, 12}}
+
+	if q := From(input).AggregateBy(func(i any) any {
+		return i.(int) % 2
+	}, 0, func(sum, i any) any {
+		return sum.(int) + i.(int)
+	}); !testQueryIteration(q, want) {
+		t.Errorf("From(%v).AggregateBy()=%v expected %v", input, toSlice(q), want)
+	}
+}
+
+func TestAggregateByT(t *testing.T) {
+	input := []string{"a", "bb", "c", "dd"}
+	want := []any{KeyValue{1, "ac"}, KeyValue{2, "bbdd"}}
+
+	if q := From(input).AggregateByT(func(s string) int {
+		return len(s)
+	}, "", func(acc, s string) string {
+		return acc + s
+	}); !testQueryIteration(q, want) {
+		t.Errorf("From(%v).AggregateByT()=%v expected %v", input, toSlice(q), want)
+	}
+}
+
+func TestAggregateByT_PanicWhenKeySelectorFnIsInvalid(t *testing.T) {
+	mustPanicWithError(t, "AggregateByT: parameter [keySelectorFn] has a invalid function signature. Expected: 'func(T)T', actual: 'func(int,int)int'", func() {
+		From([]int{1, 2}).AggregateByT(func(i, j int) int { return i }, 0, func(acc, i int) int { return acc })
+	})
+}
+
+func TestAggregateByT_PanicWhenFIsInvalid(t *testing.T) {
+	mustPanicWithError(t, "AggregateByT: parameter [f] has a invalid function signature. Expected: 'func(T,T)T', actual: 'func(int)int'", func() {
+		From([]int{1, 2}).AggregateByT(func(i int) int { return i }, 0, func(acc int) int { return acc })
+	})
+}
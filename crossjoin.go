@@ -0,0 +1,160 @@
+package linq
+
+import "iter"
+
+// CrossJoin produces the Cartesian product of two collections: every element of
+// the outer collection is combined with every element of inner, and
+// resultSelector is invoked for each pair.
+//
+// The outer collection is iterated once. The inner collection is iterated at
+// most once as well; its elements are cached as they are first produced and
+// replayed for the following outer elements, so sources that can only be
+// iterated once, such as FromChannel, are supported.
+//
+// CrossJoin preserves the order of the elements of the outer collection, and
+// for each of these elements, the order of the elements of inner.
+func (q Query) CrossJoin(inner Query,
+	resultSelector func(outer any, inner any) any) Query {
+
+	return Query{
+		Iterate: func(yield func(any) bool) {
+			innerCache := newReplay(inner)
+			defer innerCache.stop()
+
+			q.Iterate(func(outerItem any) bool {
+				for i := 0; ; i++ {
+					innerItem, ok := innerCache.at(i)
+					if !ok {
+						// An empty inner collection makes the product empty,
+						// there is no need to keep reading the outer one.
+						return i > 0
+					}
+
+					if !yield(resultSelector(outerItem, innerItem)) {
+						return false
+					}
+				}
+			})
+		},
+	}
+}
+
+// CrossJoinT is the typed version of CrossJoin.
+//
+//   - resultSelectorFn is of type "func(TOuter,TInner) TResult"
+//
+// NOTE: CrossJoin has better performance than CrossJoinT.
+func (q Query) CrossJoinT(inner Query,
+	resultSelectorFn any) Query {
+	resultSelectorGenericFunc, err := newGenericFunc(
+		"CrossJoinT", "resultSelectorFn", resultSelectorFn,
+		simpleParamValidator(newElemTypeSlice(new(genericType), new(genericType)), newElemTypeSlice(new(genericType))),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	resultSelectorFunc := func(outer any, inner any) any {
+		return resultSelectorGenericFunc.Call(outer, inner)
+	}
+
+	return q.CrossJoin(inner, resultSelectorFunc)
+}
+
+// CartesianProduct produces the n-ary Cartesian product of the passed
+// collections. Each element of the result is a []any tuple that holds one
+// element of every collection, in the order the collections were passed.
+//
+// Tuples are produced lazily in lexicographic order, i.e. the last collection
+// varies fastest. The first collection is iterated once and the remaining ones
+// are cached as they are first produced, so sources that can only be iterated
+// once, such as FromChannel, are supported. Every yielded tuple is a new slice
+// that can be safely retained by the caller.
+//
+// CartesianProduct returns an empty collection if no collections are passed or
+// if any of them is empty.
+func CartesianProduct(queries ...Query) Query {
+	return Query{
+		Iterate: func(yield func(any) bool) {
+			if len(queries) == 0 {
+				return
+			}
+
+			caches := make([]*replay, len(queries)-1)
+			for i, q := range queries[1:] {
+				caches[i] = newReplay(q)
+			}
+			defer func() {
+				for _, c := range caches {
+					c.stop()
+				}
+			}()
+
+			tuple := make([]any, len(queries))
+
+			// walk fills the tuple from position depth onwards and yields every
+			// completed combination. It returns false if the iteration has to
+			// stop, either because the consumer stopped or because one of the
+			// collections turned out to be empty.
+			var walk func(depth int) bool
+			walk = func(depth int) bool {
+				if depth == len(tuple) {
+					result := make([]any, len(tuple))
+					copy(result, tuple)
+					return yield(result)
+				}
+
+				cache := caches[depth-1]
+				for i := 0; ; i++ {
+					item, ok := cache.at(i)
+					if !ok {
+						return i > 0
+					}
+
+					tuple[depth] = item
+					if !walk(depth + 1) {
+						return false
+					}
+				}
+			}
+
+			queries[0].Iterate(func(item any) bool {
+				tuple[0] = item
+				return walk(1)
+			})
+		},
+	}
+}
+
+// replay lazily pulls the elements of a query and caches them, so that they
+// can be read by index any number of times while the underlying query is
+// iterated only once.
+type replay struct {
+	next  func() (any, bool)
+	stop  func()
+	items []any
+	done  bool
+}
+
+func newReplay(q Query) *replay {
+	next, stop := iter.Pull(q.Iterate)
+	return &replay{next: next, stop: stop}
+}
+
+// at returns the element at position i, pulling elements from the underlying
+// query as needed. It returns false if the query has less than i+1 elements.
+func (r *replay) at(i int) (any, bool) {
+	for i >= len(r.items) && !r.done {
+		item, ok := r.next()
+		if !ok {
+			r.done = true
+			break
+		}
+		r.items = append(r.items, item)
+	}
+
+	if i < len(r.items) {
+		return r.items[i], true
+	}
+	return nil, false
+}
@@ -0,0 +1,110 @@
+package linq
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCrossJoin(t *testing.T) {
+	outer := []int{1, 2, 3}
+	inner := []string{"a", "b"}
+	want := []any{
+		KeyValue{1, "a"},
+		KeyValue{1, "b"},
+		KeyValue{2, "a"},
+		KeyValue{2, "b"},
+		KeyValue{3, "a"},
+		KeyValue{3, "b"},
+	}
+
+	q := From(outer).CrossJoin(From(inner), func(outer any, inner any) any {
+		return KeyValue{outer, inner}
+	})
+
+	if !testQueryIteration(q, want) {
+		t.Errorf("From(%v).CrossJoin(%v)=%v expected %v", outer, inner, toSlice(q), want)
+	}
+}
+
+func TestCrossJoin_EmptyInner(t *testing.T) {
+	q := From([]int{1, 2, 3}).CrossJoin(From([]int{}), func(outer any, inner any) any {
+		return KeyValue{outer, inner}
+	})
+
+	if !testQueryIteration(q, nil) {
+		t.Errorf("From().CrossJoin(empty)=%v expected []", toSlice(q))
+	}
+}
+
+func TestCrossJoin_Channel(t *testing.T) {
+	c := make(chan int, 3)
+	c <- 10
+	c <- 20
+	c <- 30
+	close(c)
+
+	want := []any{11, 21, 31, 12, 22, 32}
+
+	q := From([]int{1, 2}).CrossJoin(FromChannel(c), func(outer any, inner any) any {
+		return outer.(int) + inner.(int)
+	})
+
+	if !assertQueryOutput(q, want) {
+		t.Errorf("From().CrossJoin(FromChannel())=%v expected %v", toSlice(q), want)
+	}
+}
+
+func TestCrossJoinT_PanicWhenResultSelectorFnIsInvalid(t *testing.T) {
+	mustPanicWithError(t, "CrossJoinT: parameter [resultSelectorFn] has a invalid function signature. Expected: 'func(T,T)T', actual: 'func(int,int,int)int'", func() {
+		From([]int{1, 2}).CrossJoinT(From([]int{3, 4}), func(i, j, k int) int {
+			return i + j
+		})
+	})
+}
+
+func TestCartesianProduct(t *testing.T) {
+	c := make(chan string, 2)
+	c <- "x"
+	c <- "y"
+	close(c)
+
+	tests := []struct {
+		input []Query
+		want  []any
+	}{
+		{nil, nil},
+		{[]Query{From([]int{1, 2})}, []any{[]any{1}, []any{2}}},
+		{[]Query{From([]int{1, 2}), From([]int{})}, nil},
+		{[]Query{From([]int{1, 2}), From("ab"), FromChannel(c)}, []any{
+			[]any{1, 'a', "x"}, []any{1, 'a', "y"},
+			[]any{1, 'b', "x"}, []any{1, 'b', "y"},
+			[]any{2, 'a', "x"}, []any{2, 'a', "y"},
+			[]any{2, 'b', "x"}, []any{2, 'b', "y"},
+		}},
+	}
+
+	for _, test := range tests {
+		if got := CartesianProduct(test.input...).Results(); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("CartesianProduct()=%v expected %v", got, test.want)
+		}
+	}
+}
+
+func TestCartesianProduct_EarlyStop(t *testing.T) {
+	q := CartesianProduct(Range(1, 3), Range(1, 3)).Take(2)
+	want := []any{[]any{1, 1}, []any{1, 2}}
+
+	runDryIteration(q)
+	if got := q.Results(); !reflect.DeepEqual(got, want) {
+		t.Errorf("CartesianProduct().Take(2)=%v expected %v", got, want)
+	}
+}
+
+func TestCartesianProduct_TuplesAreNotShared(t *testing.T) {
+	results := CartesianProduct(Range(1, 2), Range(1, 2)).Results()
+	results[0].([]any)[0] = 100
+
+	if got := results[1].([]any)[0]; got != 1 {
+		t.Errorf("CartesianProduct() tuples share memory, got %v expected 1", got)
+	}
+}
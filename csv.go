@@ -0,0 +1,426 @@
+package linq
+
+import (
+	"encoding"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strconv"
+)
+
+// CSVOptions configures FromCSV and ToCSV.
+type CSVOptions struct {
+	// Comma is the field delimiter. If it is zero, ',' is used.
+	Comma rune
+	// Comment, if it is not zero, is the character that starts a comment line
+	// when reading. See csv.Reader.
+	Comment rune
+	// LazyQuotes allows quotes in unquoted fields, and non-doubled quotes in
+	// quoted fields, when reading.
+	LazyQuotes bool
+	// Header reports whether the first record is a header that names the
+	// columns. Maps and structs are always read with a header. When writing
+	// maps and structs, the header is only written if Header is true.
+	Header bool
+	// Columns are the columns written by ToCSV for maps, in order. If it is
+	// empty, the sorted keys of the first map are used.
+	Columns []string
+	// UseCRLF uses \r\n as the line terminator when writing.
+	UseCRLF bool
+	// OnError, if it is not nil, is called with the error of every record
+	// that cannot be parsed or bound to a struct when reading, and the
+	// iteration goes on with the next record. Otherwise, the first such error
+	// stops the iteration. I/O errors always stop the iteration.
+	OnError func(err error)
+}
+
+// FromCSV initializes a linq query that reads the CSV records of r with
+// encoding/csv, and yields them as values of type T, which can be:
+//
+//   - []string, the fields of the record;
+//   - map[string]string, the fields of the record keyed by the names of the
+//     columns in the header;
+//   - a struct type, whose fields are set to the fields of the record in the
+//     column of the same name. The name of a column is given by the `csv`
+//     tag of the field, or is the name of the field. Fields tagged with
+//     `csv:"-"` and columns without a field are ignored. Fields can be
+//     strings, booleans, numbers, or implement encoding.TextUnmarshaler.
+//
+// The errors of a record, such as a malformed quote or a value that cannot be
+// converted to the type of a field, are *csv.ParseError values with the line
+// and column of the field. They are handled by CSVOptions.OnError, and the
+// error that stops the iteration is returned by the Err method of the query.
+//
+// FromCSV panics if T is not one of these types.
+func FromCSV[T any](r io.Reader, options CSVOptions) SourceQuery {
+	newBinder := newCSVBinder[T]()
+
+	return newSourceQuery(func(yield func(any) bool) error {
+		reader := csv.NewReader(r)
+		if options.Comma != 0 {
+			reader.Comma = options.Comma
+		}
+		reader.Comment = options.Comment
+		reader.LazyQuotes = options.LazyQuotes
+
+		var header []string
+		if options.Header || newBinder != nil {
+			var err error
+			if header, err = reader.Read(); err == io.EOF {
+				return nil
+			} else if err != nil {
+				return err
+			}
+		}
+
+		bind := func(record []string) (any, error) {
+			return record, nil
+		}
+		if newBinder != nil {
+			bind = newBinder(reader, header)
+		}
+
+		for {
+			record, err := reader.Read()
+			if err == io.EOF {
+				return nil
+			}
+
+			var item any
+			if err == nil {
+				item, err = bind(record)
+			}
+			if err != nil {
+				if _, ok := err.(*csv.ParseError); ok && options.OnError != nil {
+					options.OnError(err)
+					continue
+				}
+				return err
+			}
+
+			if !yield(item) {
+				return nil
+			}
+		}
+	})
+}
+
+// csvBinder converts a record to an element of a FromCSV query.
+type csvBinder func(record []string) (any, error)
+
+// newCSVBinder returns the function that creates the csvBinder of the records
+// read by reader after header, or nil if the records are yielded as []string.
+func newCSVBinder[T any]() func(reader *csv.Reader, header []string) csvBinder {
+	var zero T
+	switch any(zero).(type) {
+	case []string:
+		return nil
+	case map[string]string:
+		return func(reader *csv.Reader, header []string) csvBinder {
+			return func(record []string) (any, error) {
+				m := make(map[string]string, len(header))
+				for i, name := range header {
+					if i < len(record) {
+						m[name] = record[i]
+					}
+				}
+				return m, nil
+			}
+		}
+	}
+
+	t := reflect.TypeFor[T]()
+	if t.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("FromCSV: unsupported record type %v", t))
+	}
+	fields := csvFields(t)
+
+	return func(reader *csv.Reader, header []string) csvBinder {
+		// columns[i] is the field of the i-th column, if any.
+		columns := make([]*csvField, len(header))
+		for i, name := range header {
+			if j := slices.IndexFunc(fields, func(f csvField) bool { return f.name == name }); j >= 0 {
+				columns[i] = &fields[j]
+			}
+		}
+
+		return func(record []string) (any, error) {
+			v := reflect.New(t).Elem()
+			for i, field := range columns {
+				if field == nil || i >= len(record) {
+					continue
+				}
+
+				f, err := allocFieldByIndex(v, field.index)
+				if err == nil {
+					err = parseCSVField(f, record[i])
+				}
+				if err != nil {
+					line, column := reader.FieldPos(i)
+					return nil, &csv.ParseError{
+						StartLine: line,
+						Line:      line,
+						Column:    column,
+						Err:       fmt.Errorf("column %q: %w", field.name, err),
+					}
+				}
+			}
+			return v.Interface(), nil
+		}
+	}
+}
+
+// csvField is a field of a struct bound to a CSV column.
+type csvField struct {
+	name  string
+	index []int
+}
+
+// csvFields returns the fields of the struct type t that are bound to a CSV
+// column, in order.
+func csvFields(t reflect.Type) []csvField {
+	var fields []csvField
+	for _, f := range reflect.VisibleFields(t) {
+		if !f.IsExported() || f.Anonymous {
+			continue
+		}
+
+		name := f.Name
+		if tag, ok := f.Tag.Lookup("csv"); ok {
+			if tag == "-" {
+				continue
+			}
+			if tag != "" {
+				name = tag
+			}
+		}
+
+		fields = append(fields, csvField{name: name, index: f.Index})
+	}
+
+	return fields
+}
+
+// allocFieldByIndex returns the nested field of the struct v at index, like
+// v.FieldByIndex, but allocates the nil pointers to embedded structs it goes
+// through instead of panicking. It fails if such a pointer is unexported.
+func allocFieldByIndex(v reflect.Value, index []int) (reflect.Value, error) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return reflect.Value{}, fmt.Errorf("cannot set embedded pointer to unexported struct %v", v.Type().Elem())
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+
+	return v, nil
+}
+
+// parseCSVField sets the struct field v to the value of the CSV field s.
+func parseCSVField(v reflect.Value, s string) error {
+	if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(s))
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported field type %v", v.Type())
+	}
+
+	return nil
+}
+
+// formatCSVField returns the CSV field of the value of the struct field v.
+func formatCSVField(v reflect.Value) (string, error) {
+	if m, ok := v.Interface().(encoding.TextMarshaler); ok {
+		b, err := m.MarshalText()
+		return string(b), err
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits()), nil
+	}
+
+	return "", fmt.Errorf("unsupported field type %v", v.Type())
+}
+
+// ToCSV writes the elements of a collection to w as CSV records with
+// encoding/csv. Elements can be:
+//
+//   - []string, written as they are;
+//   - map[string]string, whose values are written in the order of
+//     CSVOptions.Columns, or of the sorted keys of the first map;
+//   - structs or pointers to structs, whose fields are written in order with
+//     the same mapping as FromCSV. Fields promoted through a nil embedded
+//     pointer are written as empty fields.
+//
+// For maps and structs, a header with the names of the columns is written
+// first if CSVOptions.Header is true. All the elements have to be of the same
+// kind.
+//
+// ToCSV stops at the first error, such as a write error or an unsupported
+// element, and returns it. The records before the error are written to w.
+func (q Query) ToCSV(w io.Writer, options CSVOptions) error {
+	writer := csv.NewWriter(w)
+	if options.Comma != 0 {
+		writer.Comma = options.Comma
+	}
+	writer.UseCRLF = options.UseCRLF
+
+	err := writeCSVRecords(writer, q, options)
+	writer.Flush()
+	if err != nil {
+		return err
+	}
+	return writer.Error()
+}
+
+// writeCSVRecords writes the elements of q to writer without flushing it.
+func writeCSVRecords(writer *csv.Writer, q Query, options CSVOptions) error {
+	var format func(item any) ([]string, error)
+	for item := range q.Iterate {
+		if format == nil {
+			var header []string
+			var err error
+			if format, header, err = newCSVFormatter(item, options.Columns); err != nil {
+				return err
+			}
+
+			if header != nil && options.Header {
+				if err := writer.Write(header); err != nil {
+					return err
+				}
+			}
+		}
+
+		record, err := format(item)
+		if err != nil {
+			return err
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// newCSVFormatter returns the function that converts the elements of the same
+// kind as item to CSV records, along with the header of the records, if any.
+func newCSVFormatter(item any, columns []string) (func(item any) ([]string, error), []string, error) {
+	switch m := item.(type) {
+	case []string:
+		return func(item any) ([]string, error) {
+			record, ok := item.([]string)
+			if !ok {
+				return nil, fmt.Errorf("ToCSV: %T is not a []string", item)
+			}
+			return record, nil
+		}, nil, nil
+	case map[string]string:
+		if len(columns) == 0 {
+			for name := range m {
+				columns = append(columns, name)
+			}
+			slices.Sort(columns)
+		}
+
+		return func(item any) ([]string, error) {
+			m, ok := item.(map[string]string)
+			if !ok {
+				return nil, fmt.Errorf("ToCSV: %T is not a map[string]string", item)
+			}
+
+			record := make([]string, len(columns))
+			for i, name := range columns {
+				record[i] = m[name]
+			}
+			return record, nil
+		}, columns, nil
+	}
+
+	t := reflect.TypeOf(item)
+	if t == nil || t.Kind() != reflect.Struct && (t.Kind() != reflect.Pointer || t.Elem().Kind() != reflect.Struct) {
+		return nil, nil, fmt.Errorf("ToCSV: unsupported type %T", item)
+	}
+
+	structType := t
+	if t.Kind() == reflect.Pointer {
+		structType = t.Elem()
+	}
+	fields := csvFields(structType)
+
+	header := make([]string, len(fields))
+	for i, field := range fields {
+		header[i] = field.name
+	}
+
+	return func(item any) ([]string, error) {
+		if reflect.TypeOf(item) != t {
+			return nil, fmt.Errorf("ToCSV: %T is not a %v", item, t)
+		}
+
+		v := reflect.Indirect(reflect.ValueOf(item))
+		if !v.IsValid() {
+			return nil, fmt.Errorf("ToCSV: nil %v", t)
+		}
+
+		record := make([]string, len(fields))
+		for i, field := range fields {
+			f, err := v.FieldByIndexErr(field.index)
+			if err != nil {
+				// The field is promoted through a nil embedded pointer.
+				continue
+			}
+
+			s, err := formatCSVField(f)
+			if err != nil {
+				return nil, fmt.Errorf("ToCSV: column %q: %w", field.name, err)
+			}
+			record[i] = s
+		}
+		return record, nil
+	}, header, nil
+}
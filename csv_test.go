@@ -0,0 +1,193 @@
+package linq
+
+import (
+	"encoding/csv"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+type csvPerson struct {
+	Name    string    `csv:"name"`
+	Age     int       `csv:"age"`
+	Score   float64   `csv:"score"`
+	Active  bool      `csv:"active"`
+	Joined  time.Time `csv:"joined"`
+	Comment string    `csv:"-"`
+	Email   string
+	secret  string
+}
+
+// CSVEmbedded is exported so that it can be embedded as a settable pointer.
+type CSVEmbedded struct {
+	ID int `csv:"id"`
+}
+
+type csvEmbedded struct {
+	Hidden int `csv:"hidden"`
+}
+
+type csvRow struct {
+	*CSVEmbedded
+	Name string `csv:"name"`
+}
+
+type csvHiddenRow struct {
+	*csvEmbedded
+	Name string `csv:"name"`
+}
+
+const csvPeople = `name,age,score,active,joined,Email,unknown
+alice,30,9.5,true,2024-01-02T00:00:00Z,alice@example.com,x
+"bob, jr",41,7,false,2023-06-30T12:00:00Z,,y
+`
+
+func TestFromCSV_Slices(t *testing.T) {
+	input := "a,b\n1,2\n3,4\n"
+
+	tests := []struct {
+		header bool
+		output []any
+	}{
+		{false, []any{[]string{"a", "b"}, []string{"1", "2"}, []string{"3", "4"}}},
+		{true, []any{[]string{"1", "2"}, []string{"3", "4"}}},
+	}
+
+	for _, test := range tests {
+		q := FromCSV[[]string](strings.NewReader(input), CSVOptions{Header: test.header})
+		if got := q.Results(); !reflect.DeepEqual(got, test.output) || q.Err() != nil {
+			t.Errorf("FromCSV[[]string](Header: %v)=%v, %v expected %v", test.header, got, q.Err(), test.output)
+		}
+	}
+}
+
+func TestFromCSV_Maps(t *testing.T) {
+	input := "id;name\n1;one\n# comment\n2;two\n"
+	want := []any{
+		map[string]string{"id": "1", "name": "one"},
+		map[string]string{"id": "2", "name": "two"},
+	}
+
+	q := FromCSV[map[string]string](strings.NewReader(input), CSVOptions{Comma: ';', Comment: '#'})
+	if got := q.Results(); !reflect.DeepEqual(got, want) || q.Err() != nil {
+		t.Errorf("FromCSV[map[string]string]()=%v, %v expected %v", got, q.Err(), want)
+	}
+}
+
+func TestFromCSV_Structs(t *testing.T) {
+	want := []any{
+		csvPerson{Name: "alice", Age: 30, Score: 9.5, Active: true, Joined: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), Email: "alice@example.com"},
+		csvPerson{Name: "bob, jr", Age: 41, Score: 7, Joined: time.Date(2023, 6, 30, 12, 0, 0, 0, time.UTC)},
+	}
+
+	q := FromCSV[csvPerson](strings.NewReader(csvPeople), CSVOptions{})
+	if got := q.Results(); !reflect.DeepEqual(got, want) || q.Err() != nil {
+		t.Errorf("FromCSV[csvPerson]()=%v, %v expected %v", got, q.Err(), want)
+	}
+}
+
+func TestFromCSV_Errors(t *testing.T) {
+	input := "name,age\nann,1\nbob,two\ncat,3,extra\ndan,4\n"
+
+	q := FromCSV[csvPerson](strings.NewReader(input), CSVOptions{})
+	if got := q.Results(); len(got) != 1 {
+		t.Errorf("FromCSV[csvPerson]() yielded %v, expected only ann", got)
+	}
+
+	var parseErr *csv.ParseError
+	if err := q.Err(); !errors.As(err, &parseErr) || parseErr.Line != 3 || !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("FromCSV[csvPerson]().Err()=%v expected a syntax error on line 3", err)
+	}
+
+	var errs []error
+	q = FromCSV[csvPerson](strings.NewReader(input), CSVOptions{OnError: func(err error) { errs = append(errs, err) }})
+	want := []any{csvPerson{Name: "ann", Age: 1}, csvPerson{Name: "dan", Age: 4}}
+	if got := q.Results(); !reflect.DeepEqual(got, want) || q.Err() != nil {
+		t.Errorf("FromCSV[csvPerson](OnError)=%v, %v expected %v", got, q.Err(), want)
+	}
+	if len(errs) != 2 || !errors.Is(errs[1], csv.ErrFieldCount) {
+		t.Errorf("FromCSV[csvPerson](OnError) errors=%v expected a syntax error and %v", errs, csv.ErrFieldCount)
+	}
+}
+
+func TestFromCSV_EmbeddedPointers(t *testing.T) {
+	input := "id,name\n1,ann\n2,bob\n"
+	want := []any{
+		csvRow{CSVEmbedded: &CSVEmbedded{ID: 1}, Name: "ann"},
+		csvRow{CSVEmbedded: &CSVEmbedded{ID: 2}, Name: "bob"},
+	}
+
+	q := FromCSV[csvRow](strings.NewReader(input), CSVOptions{})
+	if got := q.Results(); !reflect.DeepEqual(got, want) || q.Err() != nil {
+		t.Errorf("FromCSV[csvRow]()=%v, %v expected %v", got, q.Err(), want)
+	}
+
+	hidden := FromCSV[csvHiddenRow](strings.NewReader("hidden,name\n1,ann\n"), CSVOptions{})
+	var parseErr *csv.ParseError
+	if got := hidden.Results(); len(got) != 0 || !errors.As(hidden.Err(), &parseErr) || parseErr.Line != 2 {
+		t.Errorf("FromCSV[csvHiddenRow]()=%v, %v expected an error on line 2", got, hidden.Err())
+	}
+}
+
+func TestFromCSV_PanicWhenTypeIsUnsupported(t *testing.T) {
+	mustPanicWithError(t, "FromCSV: unsupported record type int", func() {
+		FromCSV[int](strings.NewReader(""), CSVOptions{})
+	})
+}
+
+func TestToCSV(t *testing.T) {
+	people := FromCSV[csvPerson](strings.NewReader(csvPeople), CSVOptions{}).Results()
+
+	tests := []struct {
+		input   Query
+		options CSVOptions
+		output  string
+	}{
+		{From([][]string{{"a", "b c"}, {"1", "2,3"}}), CSVOptions{}, "a,b c\n1,\"2,3\"\n"},
+		{From([]map[string]string{{"b": "1", "a": "2"}, {"a": "3"}}), CSVOptions{Header: true}, "a,b\n2,1\n3,\n"},
+		{From([]map[string]string{{"b": "1", "a": "2"}}), CSVOptions{Columns: []string{"b"}, Comma: '\t'}, "1\n"},
+		{From(people), CSVOptions{Header: true}, "name,age,score,active,joined,Email\n" +
+			"alice,30,9.5,true,2024-01-02T00:00:00Z,alice@example.com\n" +
+			"\"bob, jr\",41,7,false,2023-06-30T12:00:00Z,\n"},
+		{From([]*csvPerson{{Name: "eve"}}), CSVOptions{UseCRLF: true}, "eve,0,0,false,0001-01-01T00:00:00Z,\r\n"},
+		{From([]int{}), CSVOptions{}, ""},
+		{From([]csvRow{{Name: "ann"}, {CSVEmbedded: &CSVEmbedded{ID: 2}, Name: "bob"}}), CSVOptions{Header: true}, "id,name\n,ann\n2,bob\n"},
+	}
+
+	for i, test := range tests {
+		var b strings.Builder
+		if err := test.input.ToCSV(&b, test.options); err != nil || b.String() != test.output {
+			t.Errorf("test %d: ToCSV()=%q, %v expected %q", i, b.String(), err, test.output)
+		}
+	}
+}
+
+func TestToCSV_Errors(t *testing.T) {
+	tests := []struct {
+		input Query
+		err   string
+	}{
+		{From([]int{1}), "ToCSV: unsupported type int"},
+		{From([]any{[]string{"a"}, 1}), "ToCSV: int is not a []string"},
+		{From([]any{csvPerson{}, &csvPerson{}}), "ToCSV: *linq.csvPerson is not a linq.csvPerson"},
+		{From([]*csvPerson{nil}), "ToCSV: nil *linq.csvPerson"},
+	}
+
+	for _, test := range tests {
+		var b strings.Builder
+		if err := test.input.ToCSV(&b, CSVOptions{}); err == nil || err.Error() != test.err {
+			t.Errorf("ToCSV()=%v expected %q", err, test.err)
+		}
+	}
+}
+
+func TestToCSV_FlushesOnError(t *testing.T) {
+	var b strings.Builder
+	err := From([]any{[]string{"a", "b"}, []string{"c"}, 1}).ToCSV(&b, CSVOptions{})
+	if err == nil || b.String() != "a,b\nc\n" {
+		t.Errorf("ToCSV()=%q, %v expected %q and an error", b.String(), err, "a,b\nc\n")
+	}
+}
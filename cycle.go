@@ -0,0 +1,35 @@
+package linq
+
+// Cycle repeats the elements of a collection forever. The collection is
+// iterated only once: its elements are cached during the first pass and
+// replayed afterwards, so sources that can only be iterated once, such as
+// FromChannel, are supported.
+//
+// If the collection contains no elements, Cycle returns an empty collection.
+// Otherwise, the sequence never ends, so it has to be limited, e.g. with Take
+// or TakeWhile, before it is fully iterated.
+func (q Query) Cycle() Query {
+	return Query{
+		Iterate: func(yield func(any) bool) {
+			cache := newReplay(q)
+			defer cache.stop()
+
+			for i := 0; ; i++ {
+				item, ok := cache.at(i)
+				if !ok {
+					if i == 0 {
+						return
+					}
+
+					// The end of the collection is reached, start over.
+					i = 0
+					item, _ = cache.at(i)
+				}
+
+				if !yield(item) {
+					return
+				}
+			}
+		},
+	}
+}
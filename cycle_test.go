@@ -0,0 +1,33 @@
+package linq
+
+import "testing"
+
+func TestCycle(t *testing.T) {
+	tests := []struct {
+		input  []int
+		output []any
+	}{
+		{[]int{}, nil},
+		{[]int{1}, []any{1, 1, 1, 1, 1}},
+		{[]int{1, 2, 3}, []any{1, 2, 3, 1, 2}},
+	}
+
+	for _, test := range tests {
+		if q := From(test.input).Cycle().Take(5); !testQueryIteration(q, test.output) {
+			t.Errorf("From(%v).Cycle().Take(5)=%v expected %v", test.input, toSlice(q), test.output)
+		}
+	}
+}
+
+func TestCycle_Channel(t *testing.T) {
+	c := make(chan int, 3)
+	c <- 1
+	c <- 2
+	c <- 3
+	close(c)
+
+	want := []any{1, 2, 3, 1, 2, 3, 1}
+	if q := FromChannel(c).Cycle().Take(7); !assertQueryOutput(q, want) {
+		t.Errorf("FromChannel().Cycle().Take(7)=%v expected %v", toSlice(q), want)
+	}
+}
@@ -1,8 +1,15 @@
 package linq
 
 import (
+	"bufio"
+	"database/sql"
+	"encoding/json"
 	"fmt"
+	"math"
+	"math/big"
+	"os"
 	"strings"
+	"testing/fstest"
 	"time"
 )
 
@@ -29,6 +36,136 @@ func ExampleKeyValue_second() {
 	// map[10:true]
 }
 
+// The following code example demonstrates how to use FromCSV and ToCSV
+// to filter the records of a CSV file.
+func ExampleFromCSV() {
+	type City struct {
+		Name       string `csv:"name"`
+		Population int    `csv:"population"`
+	}
+
+	input := strings.NewReader(`name,population,country
+Tokyo,37400068,Japan
+Reykjavik,131136,Iceland
+Lagos,14368332,Nigeria
+`)
+
+	cities := FromCSV[City](input, CSVOptions{})
+	large := cities.WhereT(func(c City) bool { return c.Population > 1000000 })
+
+	if err := large.ToCSV(os.Stdout, CSVOptions{Header: true}); err != nil {
+		fmt.Println(err)
+	}
+	if err := cities.Err(); err != nil {
+		fmt.Println(err)
+	}
+	// Output:
+	// name,population
+	// Tokyo,37400068
+	// Lagos,14368332
+}
+
+// The following code example demonstrates how to use FromJSONArray
+// to query a large JSON array without loading it in memory.
+func ExampleFromJSONArray() {
+	type Order struct {
+		ID    int     `json:"id"`
+		Total float64 `json:"total"`
+	}
+
+	input := strings.NewReader(`[
+		{"id": 1, "total": 12.5},
+		{"id": 2, "total": 140},
+		{"id": 3, "total": 99.9}
+	]`)
+
+	orders := FromJSONArray[Order](input)
+	total := orders.SumFloatsByT(func(o Order) float64 { return o.Total })
+	if err := orders.Err(); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(total)
+	// Output:
+	// 252.4
+}
+
+// The following code example demonstrates how to use FromReader
+// to count the words of a text.
+func ExampleFromReader() {
+	text := strings.NewReader("the quick brown fox\njumps over the lazy dog")
+
+	words := FromReader(text, ReaderOptions{Split: bufio.ScanWords})
+	count := words.Count()
+	if err := words.Err(); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(count)
+	// Output:
+	// 9
+}
+
+// The following code example demonstrates how to use FromRows
+// to query the rows of a database/sql query as structs.
+func ExampleFromRows() {
+	type Customer struct {
+		ID      int64
+		Name    string `db:"full_name"`
+		Country string
+	}
+
+	var db *sql.DB // opened with sql.Open
+
+	rows, err := db.Query("SELECT id, full_name, country FROM customers")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	customers := FromRows(rows, ScanStruct[Customer]())
+	countries := customers.
+		SelectT(func(c Customer) string { return c.Country }).
+		Distinct().
+		Results()
+	if err := customers.Err(); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(countries)
+}
+
+// The following code example demonstrates how to use FromFS
+// to find the Go files of a file tree, skipping the vendor directories.
+func ExampleFromFS() {
+	fsys := fstest.MapFS{ // or os.DirFS("/path/to/module")
+		"go.mod":                  {},
+		"main.go":                 {Data: []byte("package main")},
+		"internal/util/util.go":   {Data: []byte("package util")},
+		"internal/util/doc.txt":   {},
+		"vendor/example.com/x.go": {Data: []byte("package x")},
+	}
+
+	entries := FromFS(fsys, ".", FSOptions{
+		SkipDir: func(entry FSEntry) bool { return entry.DirEntry.Name() == "vendor" },
+	})
+	paths := entries.
+		WhereT(func(entry FSEntry) bool { return strings.HasSuffix(entry.Path, ".go") }).
+		SelectT(func(entry FSEntry) string { return entry.Path }).
+		Results()
+	if err := entries.Err(); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(paths)
+	// Output:
+	// [internal/util/util.go main.go]
+}
+
 // The following code example demonstrates how
 // to use Range to generate a slice of values.
 func ExampleRange() {
@@ -57,6 +194,38 @@ func ExampleRange() {
 	//100
 }
 
+// The following code example demonstrates how to use RangeStep
+// to generate a slice of values that counts down.
+func ExampleRangeStep() {
+	var countdown []float64
+	RangeStep(1.0, 0.0, -0.25).ToSlice(&countdown)
+
+	fmt.Println(countdown)
+	// Output:
+	// [1 0.75 0.5 0.25]
+}
+
+// The following code example demonstrates how to use Unfold
+// to generate the Collatz sequence of a number.
+func ExampleUnfold() {
+	collatz := Unfold(6, func(n int) (int, int, bool) {
+		if n == 0 {
+			return 0, 0, false
+		}
+		if n == 1 {
+			return 1, 0, true
+		}
+		if n%2 == 0 {
+			return n, n / 2, true
+		}
+		return n, 3*n + 1, true
+	})
+
+	fmt.Println(collatz.Results())
+	// Output:
+	// [6 3 10 5 16 8 4 2 1]
+}
+
 // The following code example demonstrates how to use Repeat
 // to generate a slice of a repeated value.
 func ExampleRepeat() {
@@ -208,6 +377,40 @@ func ExampleOrderedQuery_DistinctBy() {
 	// orange 4
 }
 
+// The following code example demonstrates how to use RankBy to rank
+// the elements of a sorted slice within partitions.
+func ExampleOrderedQuery_RankBy() {
+	type Sale struct {
+		Region string
+		Seller string
+		Amount int
+	}
+
+	sales := []Sale{
+		{"north", "ann", 300},
+		{"south", "bob", 250},
+		{"north", "cat", 450},
+		{"south", "dan", 250},
+		{"north", "eve", 300},
+		{"south", "fay", 100},
+	}
+
+	From(sales).
+		OrderByDescending(func(s any) any { return s.(Sale).Amount }).
+		RankBy(func(s any) any { return s.(Sale).Region }).
+		ForEach(func(r any) {
+			sale := r.(Ranked).Value.(Sale)
+			fmt.Printf("%s #%d %s\n", sale.Region, r.(Ranked).Rank, sale.Seller)
+		})
+	// Output:
+	// north #1 cat
+	// north #2 ann
+	// north #2 eve
+	// south #1 bob
+	// south #1 dan
+	// south #3 fay
+}
+
 // The following code example demonstrates how to use ThenBy to perform
 // a secondary ordering of the elements in a slice.
 func ExampleOrderedQuery_ThenBy() {
@@ -374,6 +577,52 @@ func ExampleQuery_CountWith() {
 	// 6
 }
 
+// The following code example demonstrates how to use CountBy
+// to count the words of a sentence by their first letter.
+func ExampleQuery_CountBy() {
+	words := strings.Fields("the quick brown fox jumps over the lazy dog")
+
+	q := From(words).CountBy(func(w any) any {
+		return w.(string)[:1]
+	})
+
+	fmt.Println(q.Results())
+	// Output:
+	// [{t 2} {q 1} {b 1} {f 1} {j 1} {o 1} {l 1} {d 1}]
+}
+
+// The following code example demonstrates how to use CrossJoin
+// to combine every size with every color.
+func ExampleQuery_CrossJoin() {
+	sizes := []string{"S", "M", "L"}
+	colors := []string{"red", "blue"}
+
+	q := From(sizes).
+		CrossJoin(From(colors),
+			func(outer any, inner any) any {
+				return outer.(string) + "-" + inner.(string)
+			},
+		)
+
+	fmt.Println(q.Results())
+	// Output:
+	// [S-red S-blue M-red M-blue L-red L-blue]
+}
+
+// The following code example demonstrates how to use CartesianProduct
+// to enumerate all combinations of three collections.
+func ExampleCartesianProduct() {
+	q := CartesianProduct(
+		From([]int{1, 2}),
+		From([]string{"a", "b"}),
+		From([]bool{true}),
+	)
+
+	fmt.Println(q.Results())
+	// Output:
+	// [[1 a true] [1 b true] [2 a true] [2 b true]]
+}
+
 // The following example demonstrates how to use the DefaultIfEmpty
 // method on the results of a group join to perform a left outer join.
 //
@@ -553,6 +802,19 @@ func ExampleQuery_ExceptBy() {
 
 }
 
+// The following code example demonstrates how to use ExceptAll
+// to reconcile two ledgers that may contain duplicate entries.
+func ExampleQuery_ExceptAll() {
+	expected := []int{100, 250, 100, 75, 100}
+	booked := []int{100, 75, 100}
+
+	q := From(expected).ExceptAll(From(booked))
+
+	fmt.Println(q.Results())
+	// Output:
+	// [250 100]
+}
+
 // The following code example demonstrates how to use First
 // to return the first element of an array.
 func ExampleQuery_First() {
@@ -582,6 +844,60 @@ func ExampleQuery_FirstWith() {
 
 }
 
+// The following code example demonstrates how to use FlattenDeep
+// to iterate over the numbers of an arbitrarily nested JSON array.
+func ExampleQuery_FlattenDeep() {
+	var data any
+	if err := json.Unmarshal([]byte(`[1, [2, 3], [[4], [5, [6]]]]`), &data); err != nil {
+		panic(err)
+	}
+
+	q := From(data).FlattenDeep(-1)
+
+	fmt.Println(q.Results())
+	// Output:
+	// [1 2 3 4 5 6]
+}
+
+// The following code example demonstrates how to use Histogram
+// to bucket response times.
+func ExampleQuery_Histogram() {
+	responseTimes := []int{12, 48, 230, 95, 61, 1500, 33}
+
+	for _, bucket := range From(responseTimes).Histogram(50, 100, 1000) {
+		fmt.Printf("[%v, %v): %d\n", bucket.Lower, bucket.Upper, bucket.Count)
+	}
+	// Output:
+	// [-Inf, 50): 3
+	// [50, 100): 2
+	// [100, 1000): 1
+	// [1000, +Inf): 1
+}
+
+// The following code example demonstrates how to use HistogramFunc
+// to bucket exam scores by tens.
+func ExampleQuery_HistogramFunc() {
+	scores := []int{42, 58, 61, 67, 73, 88, 95, 100, 12}
+
+	counts := From(scores).HistogramFunc(5, func(x float64) int {
+		return int(math.Floor(x/10)) - 5
+	})
+
+	fmt.Println("below 50:", counts.Underflow)
+	for i, count := range counts.Buckets {
+		fmt.Printf("[%d, %d): %d\n", 50+i*10, 60+i*10, count)
+	}
+	fmt.Println("100 or more:", counts.Overflow)
+	// Output:
+	// below 50: 2
+	// [50, 60): 1
+	// [60, 70): 2
+	// [70, 80): 1
+	// [80, 90): 1
+	// [90, 100): 1
+	// 100 or more: 1
+}
+
 // The following code example demonstrates how to use Intersect
 // to return the elements that appear in each of two slices of integers.
 func ExampleQuery_Intersect() {
@@ -756,6 +1072,23 @@ func ExampleQuery_Concat() {
 	// [1 2 3 4 5 6]
 }
 
+// The following code example demonstrates how to use GroupAdjacent
+// to run-length encode a string.
+func ExampleQuery_GroupAdjacent() {
+	From("aaabccdddd").
+		GroupAdjacent(
+			func(r any) any { return r },
+			func(r any) any { return r },
+		).
+		ForEach(func(g any) {
+			group := g.(Group)
+			fmt.Printf("%c%d ", group.Key, len(group.Group))
+		})
+	fmt.Println()
+	// Output:
+	// a3 b1 c2 d4
+}
+
 func ExampleQuery_GroupBy() {
 	input := []int{1, 2, 3, 4, 5, 6, 7, 8, 9}
 
@@ -877,6 +1210,64 @@ func ExampleQuery_OrderBy() {
 	// [10 8 6 4 2 9 7 5 3 1]
 }
 
+// The following code example demonstrates how to use Partition
+// to split a slice into passing and failing scores in a single pass.
+func ExampleQuery_Partition() {
+	scores := []int{72, 45, 90, 38, 65}
+
+	passed, failed := From(scores).Partition(func(i any) bool {
+		return i.(int) >= 50
+	})
+
+	fmt.Println(passed, failed)
+	// Output:
+	// [72 90 65] [45 38]
+}
+
+// The following code example demonstrates how to use Pairwise
+// to compute the differences between consecutive readings.
+func ExampleQuery_Pairwise() {
+	readings := []float64{12.5, 13.0, 12.25, 14.0}
+
+	var deltas []float64
+	From(readings).
+		Pairwise(func(previous, current any) any {
+			return current.(float64) - previous.(float64)
+		}).
+		ToSlice(&deltas)
+
+	fmt.Println(deltas)
+	// Output:
+	// [0.5 -0.75 1.75]
+}
+
+// The following code example demonstrates how to use Lag
+// to pair each element of a slice with the one before it.
+func ExampleQuery_Lag() {
+	days := []string{"mon", "tue", "wed"}
+
+	From(days).Lag(1, "-").ForEach(func(s any) {
+		fmt.Println(s.(Shifted).Shifted, "->", s.(Shifted).Value)
+	})
+	// Output:
+	// - -> mon
+	// mon -> tue
+	// tue -> wed
+}
+
+// The following code example demonstrates how to use Percentile
+// to compute the 90th percentile of request latencies.
+func ExampleQuery_Percentile() {
+	latencies := []int{120, 80, 95, 300, 110, 105, 90, 100, 85, 130}
+
+	p90 := From(latencies).Percentile(90, PercentileLinear)
+	median := From(latencies).Median()
+
+	fmt.Printf("%.1f %.1f\n", p90, median)
+	// Output:
+	// 147.0 102.5
+}
+
 // The following code example demonstrates how to use Prepend
 // to include an elements in the first position of a slice.
 func ExampleQuery_Prepend() {
@@ -905,6 +1296,41 @@ func ExampleQuery_Reverse() {
 	// elppa
 }
 
+// The following code example demonstrates how to use RollingAverageBy
+// to compute the moving average of a series over time.
+func ExampleQuery_RollingAverageBy() {
+	type Sample struct {
+		At    time.Time
+		Value float64
+	}
+
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	samples := []Sample{
+		{start, 10},
+		{start.Add(2 * time.Minute), 20},
+		{start.Add(4 * time.Minute), 60},
+		{start.Add(7 * time.Minute), 40},
+	}
+
+	// Average over the last 5 minutes.
+	window := RollingWindow{
+		KeySelector: func(s any) any { return s.(Sample).At },
+		Range:       5 * time.Minute,
+	}
+
+	From(samples).
+		RollingAverageBy(window, func(s any) any { return s.(Sample).Value }).
+		ForEach(func(r any) {
+			sample := r.(Rolling).Value.(Sample)
+			fmt.Printf("%s %.0f\n", sample.At.Format("15:04"), r.(Rolling).Aggregate)
+		})
+	// Output:
+	// 12:00 10
+	// 12:02 15
+	// 12:04 30
+	// 12:07 50
+}
+
 // The following code example demonstrates how to use Select
 // to project over a slice of values.
 func ExampleQuery_Select() {
@@ -1241,6 +1667,65 @@ func ExampleQuery_Sort() {
 
 }
 
+// The following code example demonstrates how to use a Spill
+// to sort a collection that doesn't fit in memory.
+func ExampleSpill_OrderBy() {
+	spill, err := NewSpill(SpillOptions{MaxElements: 3})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer spill.Close()
+
+	words := []string{"kiwi", "fig", "banana", "apple", "plum", "cherry", "date"}
+
+	var sorted []string
+	spill.OrderByT(From(words), func(w string) int { return len(w) }).
+		ThenByT(func(w string) string { return w }).
+		ToSlice(&sorted)
+
+	if err := spill.Err(); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(sorted)
+	// Output:
+	// [fig date kiwi plum apple banana cherry]
+}
+
+// The following code example demonstrates how to use StdDev
+// to compute the sample standard deviation of a slice of numbers.
+func ExampleQuery_StdDev() {
+	values := []float64{2, 4, 4, 4, 5, 5, 7, 9}
+
+	fmt.Printf("%.4f %.4f\n", From(values).StdDev(), From(values).PopulationStdDev())
+	// Output:
+	// 2.1381 2.0000
+}
+
+// The following code example demonstrates how to use SumBigBy
+// to add up monetary amounts without rounding errors.
+func ExampleQuery_SumBigBy() {
+	type Payment struct {
+		Amount *big.Rat
+	}
+
+	payments := []Payment{
+		{big.NewRat(10, 100)},
+		{big.NewRat(20, 100)},
+		{big.NewRat(1999, 100)},
+	}
+
+	total := FromSlice(payments).SumBigBy(func(p any) any {
+		return p.(Payment).Amount
+	})
+
+	fmt.Println(total.(*big.Rat).FloatString(2))
+	// Output:
+	// 20.29
+}
+
 // The following code example demonstrates how to use SumFloats
 // to sum the values of a slice.
 func ExampleQuery_SumFloats() {
@@ -1254,6 +1739,18 @@ func ExampleQuery_SumFloats() {
 
 }
 
+// The following code example demonstrates how to use SumIntsChecked
+// to detect an overflow instead of returning a wrapped around sum.
+func ExampleQuery_SumIntsChecked() {
+	balances := []int64{math.MaxInt64, 1}
+
+	if _, err := From(balances).SumIntsChecked(); err != nil {
+		fmt.Println(err)
+	}
+	// Output:
+	// linq: integer overflow
+}
+
 // The following code example demonstrates how to use SumInts
 // to sum the values of a slice.
 func ExampleQuery_SumInts() {
@@ -1369,6 +1866,36 @@ func ExampleQuery_ToChannelT() {
 	// ten
 }
 
+// The following code example demonstrates how to use ToJSONArray
+// to stream the results of a query as an indented JSON array.
+func ExampleQuery_ToJSONArray() {
+	type Point struct {
+		X int `json:"x"`
+		Y int `json:"y"`
+	}
+
+	points := Range(1, 3).SelectT(func(i int) Point { return Point{i, i * i} })
+
+	if err := points.ToJSONArray(os.Stdout, JSONOptions{Indent: "  "}); err != nil {
+		fmt.Println(err)
+	}
+	// Output:
+	// [
+	//   {
+	//     "x": 1,
+	//     "y": 1
+	//   },
+	//   {
+	//     "x": 2,
+	//     "y": 4
+	//   },
+	//   {
+	//     "x": 3,
+	//     "y": 9
+	//   }
+	// ]
+}
+
 // The following code example demonstrates how to use ToMap to populate a map.
 func ExampleQuery_ToMap() {
 	type Product struct {
@@ -1430,6 +1957,37 @@ func ExampleQuery_ToSlice() {
 	// [1 2 3 4 5 6 7 8 9 10]
 }
 
+// The following code example demonstrates how to use TopologicalSort
+// to order migration scripts by their dependencies.
+func ExampleQuery_TopologicalSort() {
+	type Migration struct {
+		Name      string
+		DependsOn []string
+	}
+
+	migrations := []Migration{
+		{"add_orders", []string{"create_users"}},
+		{"create_users", nil},
+		{"add_index", []string{"add_orders", "create_users"}},
+	}
+
+	sorted, err := FromSlice(migrations).TopologicalSort(
+		func(m any) any { return m.(Migration).Name },
+		func(m any) Query { return FromSlice(m.(Migration).DependsOn) },
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, m := range sorted {
+		fmt.Println(m.(Migration).Name)
+	}
+	// Output:
+	// create_users
+	// add_orders
+	// add_index
+}
+
 // The following code example demonstrates how to use Union
 // to obtain the union of two slices of integers.
 func ExampleQuery_Union() {
@@ -1490,6 +2048,38 @@ func ExampleQuery_Zip() {
 	// [[1 one] [2 two] [3 three]]
 }
 
+// The following code example demonstrates how to use ZipN
+// to align three columns into rows.
+func ExampleZipN() {
+	names := []string{"apple", "banana", "cherry"}
+	prices := []float64{1.5, 0.25, 3}
+	stock := []int{10, 0, 42}
+
+	q := ZipN(From(names), From(prices), From(stock))
+
+	fmt.Println(q.Results())
+	// Output:
+	// [[apple 1.5 10] [banana 0.25 0] [cherry 3 42]]
+}
+
+// The following code example demonstrates how to use ZipLongest
+// to pad the shorter of two slices.
+func ExampleQuery_ZipLongest() {
+	letters := []string{"a", "b", "c", "d"}
+	numbers := []string{"1", "2"}
+
+	q := From(letters).
+		ZipLongest(From(numbers), "-",
+			func(a, b any) any {
+				return a.(string) + b.(string)
+			},
+		)
+
+	fmt.Println(q.Results())
+	// Output:
+	// [a1 b2 c- d-]
+}
+
 // The following code example demonstrates how to use ThenByDescendingT to perform
 // a order in a slice of dates by year, and then by month descending.
 func ExampleOrderedQuery_ThenByDescendingT() {
@@ -2626,6 +3216,43 @@ func ExampleFromChannel() {
 	// [one two three]
 }
 
+// The following code example demonstrates how to use TraverseNodes
+// to print an org chart with indentation.
+func ExampleTraverseNodes() {
+	reports := map[string][]string{
+		"Alice": {"Bob", "Carol"},
+		"Bob":   {"Dave"},
+	}
+
+	TraverseNodes("Alice", func(i any) Query {
+		return FromSlice(reports[i.(string)])
+	}, TraverseOptions{}).ForEach(func(i any) {
+		node := i.(TreeNode)
+		fmt.Println(strings.Repeat("  ", node.Depth) + node.Value.(string))
+	})
+	// Output:
+	// Alice
+	//   Bob
+	//     Dave
+	//   Carol
+}
+
+// The following code example demonstrates how to use the generic Sum, Min
+// and Max functions with a named numeric type.
+func ExampleSum() {
+	type Celsius float64
+
+	readings := []Celsius{21.5, 19, 23.25}
+
+	total := Sum[Celsius](FromSlice(readings))
+	lowest, _ := Min[Celsius](FromSlice(readings))
+	highest, _ := Max[Celsius](FromSlice(readings))
+
+	fmt.Println(total, lowest, highest)
+	// Output:
+	// 63.75 19 23.25
+}
+
 type MyQuery Query
 
 func (q MyQuery) GreaterThan(threshold int) Query {
@@ -64,3 +64,133 @@ func (q Query) ExceptByT(q2 Query,
 
 	return q.ExceptBy(q2, selectorFunc)
 }
+
+// ExceptAll produces the multiset difference of two sequences. Unlike Except,
+// it respects the multiplicity of the elements: every element of the second
+// sequence removes at most one equal element from the first sequence, like
+// SQL's EXCEPT ALL.
+//
+// ExceptAll preserves the order of the elements of the first sequence.
+func (q Query) ExceptAll(q2 Query) Query {
+	return q.ExceptAllBy(q2, func(item any) any { return item })
+}
+
+// ExceptAllBy invokes a transform function on each element of a collection
+// and produces the multiset difference of two sequences. Every element of the
+// second sequence removes at most one element with the same key from the first
+// sequence.
+func (q Query) ExceptAllBy(q2 Query, selector func(any) any) Query {
+	return Query{
+		Iterate: func(yield func(any) bool) {
+			counts := make(map[any]int)
+			for item := range q2.Iterate {
+				counts[selector(item)]++
+			}
+
+			q.Iterate(func(item any) bool {
+				key := selector(item)
+				if counts[key] > 0 {
+					counts[key]--
+					return true
+				}
+				return yield(item)
+			})
+		},
+	}
+}
+
+// ExceptAllByT is the typed version of ExceptAllBy.
+//
+//   - selectorFn is of type "func(TSource) TSource"
+//
+// NOTE: ExceptAllBy has better performance than ExceptAllByT.
+func (q Query) ExceptAllByT(q2 Query,
+	selectorFn any) Query {
+	selectorGenericFunc, err := newGenericFunc(
+		"ExceptAllByT", "selectorFn", selectorFn,
+		simpleParamValidator(newElemTypeSlice(new(genericType)), newElemTypeSlice(new(genericType))),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	selectorFunc := func(item any) any {
+		return selectorGenericFunc.Call(item)
+	}
+
+	return q.ExceptAllBy(q2, selectorFunc)
+}
+
+// SymmetricExcept produces the symmetric difference of two sequences. The
+// symmetric difference is the distinct members of the first sequence that
+// don't appear in the second sequence, followed by the distinct members of
+// the second sequence that don't appear in the first sequence. Each member is
+// yielded once, at its first occurrence, even if it is repeated in its
+// sequence.
+func (q Query) SymmetricExcept(q2 Query) Query {
+	return q.SymmetricExceptBy(q2, func(item any) any { return item })
+}
+
+// SymmetricExceptBy invokes a transform function on each element of both
+// collections and produces the symmetric difference of two sequences. Elements
+// are compared by their keys, and each key is yielded at most once.
+func (q Query) SymmetricExceptBy(q2 Query, selector func(any) any) Query {
+	return Query{
+		Iterate: func(yield func(any) bool) {
+			var items2, keys2 []any
+			set2 := make(map[any]struct{})
+			for item := range q2.Iterate {
+				key := selector(item)
+				if _, seen := set2[key]; !seen {
+					items2, keys2 = append(items2, item), append(keys2, key)
+					set2[key] = struct{}{}
+				}
+			}
+
+			set1 := make(map[any]struct{})
+			for item := range q.Iterate {
+				key := selector(item)
+				if _, seen := set1[key]; seen {
+					continue
+				}
+				set1[key] = struct{}{}
+
+				if _, seen := set2[key]; !seen {
+					if !yield(item) {
+						return
+					}
+				}
+			}
+
+			for i, item := range items2 {
+				if _, seen := set1[keys2[i]]; !seen {
+					if !yield(item) {
+						return
+					}
+				}
+			}
+		},
+	}
+}
+
+// SymmetricExceptByT is the typed version of SymmetricExceptBy.
+//
+//   - selectorFn is of type "func(TSource) TSource"
+//
+// NOTE: SymmetricExceptBy has better performance than SymmetricExceptByT.
+func (q Query) SymmetricExceptByT(q2 Query,
+	selectorFn any) Query {
+	selectorGenericFunc, err := newGenericFunc(
+		"SymmetricExceptByT", "selectorFn", selectorFn,
+		simpleParamValidator(newElemTypeSlice(new(genericType)), newElemTypeSlice(new(genericType))),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	selectorFunc := func(item any) any {
+		return selectorGenericFunc.Call(item)
+	}
+
+	return q.SymmetricExceptBy(q2, selectorFunc)
+}
@@ -29,3 +29,68 @@ func TestExceptByT_PanicWhenSelectorFnIsInvalid(t *testing.T) {
 		From([]int{1, 1, 1, 2, 1, 2, 3, 4, 2}).ExceptByT(From([]int{1}), func(x, item int) int { return item + 2 })
 	})
 }
+
+func TestExceptAll(t *testing.T) {
+	input1 := []int{1, 2, 3, 4, 5, 1, 2, 5, 1}
+	input2 := []int{1, 2, 1, 6}
+	want := []any{3, 4, 5, 2, 5, 1}
+
+	if q := From(input1).ExceptAll(From(input2)); !testQueryIteration(q, want) {
+		t.Errorf("From(%v).ExceptAll(%v)=%v expected %v", input1, input2, toSlice(q), want)
+	}
+}
+
+func TestExceptAllBy(t *testing.T) {
+	input1 := []int{1, 2, 3, 4, 5}
+	input2 := []int{7, 9}
+	want := []any{2, 4, 5}
+
+	if q := From(input1).ExceptAllBy(From(input2), func(i any) any {
+		return i.(int) % 2
+	}); !testQueryIteration(q, want) {
+		t.Errorf("From(%v).ExceptAllBy(%v)=%v expected %v", input1, input2, toSlice(q), want)
+	}
+}
+
+func TestExceptAllByT_PanicWhenSelectorFnIsInvalid(t *testing.T) {
+	mustPanicWithError(t, "ExceptAllByT: parameter [selectorFn] has a invalid function signature. Expected: 'func(T)T', actual: 'func(int,int)int'", func() {
+		From([]int{1, 1, 1, 2, 1, 2, 3, 4, 2}).ExceptAllByT(From([]int{1}), func(x, item int) int { return item + 2 })
+	})
+}
+
+func TestSymmetricExcept(t *testing.T) {
+	tests := []struct {
+		input1 []int
+		input2 []int
+		output []any
+	}{
+		{[]int{1, 2, 3, 3, 4}, []int{3, 5, 1, 6}, []any{2, 4, 5, 6}},
+		{[]int{2, 2}, []int{}, []any{2}},
+		{[]int{}, []int{7, 8, 7}, []any{7, 8}},
+		{[]int{1, 2, 1, 2}, []int{2, 3, 3}, []any{1, 3}},
+	}
+
+	for _, test := range tests {
+		if q := From(test.input1).SymmetricExcept(From(test.input2)); !testQueryIteration(q, test.output) {
+			t.Errorf("From(%v).SymmetricExcept(%v)=%v expected %v", test.input1, test.input2, toSlice(q), test.output)
+		}
+	}
+}
+
+func TestSymmetricExceptBy(t *testing.T) {
+	input1 := []string{"apple", "banana", "cherry", "blueberry"}
+	input2 := []string{"avocado", "date", "durian"}
+	want := []any{"banana", "cherry", "date"}
+
+	if q := From(input1).SymmetricExceptBy(From(input2), func(i any) any {
+		return i.(string)[0]
+	}); !testQueryIteration(q, want) {
+		t.Errorf("From(%v).SymmetricExceptBy(%v)=%v expected %v", input1, input2, toSlice(q), want)
+	}
+}
+
+func TestSymmetricExceptByT_PanicWhenSelectorFnIsInvalid(t *testing.T) {
+	mustPanicWithError(t, "SymmetricExceptByT: parameter [selectorFn] has a invalid function signature. Expected: 'func(T)T', actual: 'func(int,int)int'", func() {
+		From([]int{1, 2}).SymmetricExceptByT(From([]int{1}), func(x, item int) int { return item + 2 })
+	})
+}
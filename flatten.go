@@ -0,0 +1,71 @@
+package linq
+
+import "reflect"
+
+// Flatten flattens one level of nested collections into one collection.
+//
+// Elements that are slices, arrays, maps, channels, Iterable implementations
+// or Query values are replaced by their elements, detected in the same way as
+// From does; map entries are yielded as KeyValue. Any other element, including
+// strings and nil, is yielded as is. This makes Flatten the equivalent of
+// calling SelectMany with From as the selector, without panicking on elements
+// that are not collections.
+func (q Query) Flatten() Query {
+	return q.FlattenDeep(1)
+}
+
+// FlattenDeep flattens up to maxDepth levels of nested collections into one
+// collection. A negative maxDepth flattens arbitrarily nested collections, and
+// a maxDepth of zero returns the elements unchanged. Nested collections that
+// are deeper than maxDepth are yielded as is.
+//
+// Elements are detected as collections in the same way as Flatten does, so
+// strings are never split into runes. This is useful to iterate over
+// arbitrarily nested data such as decoded JSON arrays.
+func (q Query) FlattenDeep(maxDepth int) Query {
+	return Query{
+		Iterate: func(yield func(any) bool) {
+			flattenInto(q, maxDepth, yield)
+		},
+	}
+}
+
+// flattenInto yields the elements of q, recursively replacing the collections
+// among them by their elements while depth is not exhausted. It returns false
+// if the consumer stopped the iteration.
+func flattenInto(q Query, depth int, yield func(any) bool) bool {
+	keepGoing := true
+	q.Iterate(func(item any) bool {
+		if depth != 0 {
+			if inner, ok := asCollection(item); ok {
+				keepGoing = flattenInto(inner, depth-1, yield)
+				return keepGoing
+			}
+		}
+
+		keepGoing = yield(item)
+		return keepGoing
+	})
+
+	return keepGoing
+}
+
+// asCollection returns a Query over item if it is a collection supported by
+// From, except for strings which are treated as scalar values.
+func asCollection(item any) (Query, bool) {
+	switch v := item.(type) {
+	case nil, string:
+		return Query{}, false
+	case Query:
+		return v, true
+	case Iterable:
+		return FromIterable(v), true
+	}
+
+	switch reflect.TypeOf(item).Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map, reflect.Chan:
+		return From(item), true
+	default:
+		return Query{}, false
+	}
+}
@@ -0,0 +1,71 @@
+package linq
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFlatten(t *testing.T) {
+	tests := []struct {
+		input  any
+		output []any
+	}{
+		{[][]int{{1, 2}, {}, {3}}, []any{1, 2, 3}},
+		{[]any{1, []int{2, 3}, "ab", nil, [1]int{4}}, []any{1, 2, 3, "ab", nil, 4}},
+		{[]any{map[string]int{"a": 1}}, []any{KeyValue{"a", 1}}},
+		{[]any{foo{f1: 1, f2: true, f3: "s"}, Range(5, 2)}, []any{1, true, "s", 5, 6}},
+	}
+
+	for _, test := range tests {
+		if q := From(test.input).Flatten(); !testQueryIteration(q, test.output) {
+			t.Errorf("From(%v).Flatten()=%v expected %v", test.input, toSlice(q), test.output)
+		}
+	}
+}
+
+func TestFlatten_Channel(t *testing.T) {
+	c := make(chan int, 2)
+	c <- 2
+	c <- 3
+	close(c)
+
+	if q := From([]any{1, c}).Flatten(); !assertQueryOutput(q, []any{1, 2, 3}) {
+		t.Errorf("From().Flatten()=%v expected [1 2 3]", toSlice(q))
+	}
+}
+
+func TestFlattenDeep(t *testing.T) {
+	input := []any{1, []any{2, []any{3, []any{4, "five"}}}, []int{6}}
+
+	tests := []struct {
+		maxDepth int
+		output   []any
+	}{
+		{-1, []any{1, 2, 3, 4, "five", 6}},
+		{2, []any{1, 2, 3, []any{4, "five"}, 6}},
+		{1, []any{1, 2, []any{3, []any{4, "five"}}, 6}},
+	}
+
+	for _, test := range tests {
+		q := From(input).FlattenDeep(test.maxDepth)
+		runDryIteration(q)
+
+		// Elements might be slices which are not comparable, hence the
+		// comparison of their string representations.
+		if got, want := fmt.Sprint(toSlice(q)), fmt.Sprint(test.output); got != want {
+			t.Errorf("From(%v).FlattenDeep(%d)=%v expected %v", input, test.maxDepth, got, want)
+		}
+	}
+
+	if q := From(input).FlattenDeep(0); q.Count() != 3 {
+		t.Errorf("From(%v).FlattenDeep(0)=%v expected the input", input, toSlice(q))
+	}
+}
+
+func TestFlattenDeep_EarlyStop(t *testing.T) {
+	input := []any{[]any{1, []any{2, 3}}, 4}
+
+	if q := From(input).FlattenDeep(-1).Take(2); !testQueryIteration(q, []any{1, 2}) {
+		t.Errorf("From(%v).FlattenDeep(-1).Take(2)=%v expected [1 2]", input, toSlice(q))
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"iter"
 	"reflect"
+	"time"
 )
 
 // Query is the type returned from query functions. It can be iterated manually
@@ -188,6 +189,100 @@ func Range(start, count int) Query {
 	}
 }
 
+// RangeStep generates a sequence of numbers from start up to, but not
+// including, end, by increments of step. A negative step counts down from
+// start to end. Since time.Duration is an integer type, RangeStep also
+// generates ranges of durations.
+//
+// The n-th number is computed as start + n*step, so float ranges don't
+// accumulate rounding errors, and the sequence ends before the numbers
+// overflow T. RangeStep panics if step is zero.
+func RangeStep[T Number](start, end, step T) Query {
+	if step == 0 {
+		panic("RangeStep: step must not be zero")
+	}
+
+	return Query{
+		Iterate: func(yield func(any) bool) {
+			var previous T
+			for i := T(0); ; i++ {
+				value := start + i*step
+				if step > 0 && value >= end || step < 0 && value <= end {
+					return
+				}
+				if i > 0 && (step > 0 && value <= previous || step < 0 && value >= previous) {
+					// The numbers overflowed T.
+					return
+				}
+				previous = value
+
+				if !yield(value) {
+					return
+				}
+			}
+		},
+	}
+}
+
+// TimeRange generates a sequence of times from start up to, but not including,
+// end, by increments of step. A negative step goes back in time from start to
+// end. TimeRange panics if step is zero.
+func TimeRange(start, end time.Time, step time.Duration) Query {
+	if step == 0 {
+		panic("TimeRange: step must not be zero")
+	}
+
+	return Query{
+		Iterate: func(yield func(any) bool) {
+			for value := start; ; value = value.Add(step) {
+				if step > 0 && !value.Before(end) || step < 0 && !value.After(end) {
+					return
+				}
+
+				if !yield(value) {
+					return
+				}
+			}
+		},
+	}
+}
+
+// Generate generates an infinite sequence that starts with seed, and in which
+// every element is the result of next applied to the element before it, e.g.
+// Generate(1, func(i int) int { return i * 2 }) yields the powers of two.
+//
+// The sequence never ends, so it has to be limited, e.g. with Take or
+// TakeWhile, before it is fully iterated.
+func Generate[T any](seed T, next func(T) T) Query {
+	return Query{
+		Iterate: func(yield func(any) bool) {
+			for value := seed; ; value = next(value) {
+				if !yield(value) {
+					return
+				}
+			}
+		},
+	}
+}
+
+// Unfold generates a sequence from an initial state. The producer f is called
+// with the current state and returns the next element, the next state, and
+// whether there is such an element. The sequence ends as soon as f returns
+// false.
+func Unfold[S, T any](state S, f func(S) (T, S, bool)) Query {
+	return Query{
+		Iterate: func(yield func(any) bool) {
+			for s := state; ; {
+				item, next, ok := f(s)
+				if !ok || !yield(item) {
+					return
+				}
+				s = next
+			}
+		},
+	}
+}
+
 // Repeat generates a sequence that contains one repeated value.
 func Repeat[T any](value T, count int) Query {
 	return Query{
@@ -178,3 +178,73 @@ func TestRepeat(t *testing.T) {
 		t.Errorf("Repeat(1, 5)=%v expected %v", toSlice(q), w)
 	}
 }
+
+func TestRangeStep(t *testing.T) {
+	tests := []struct {
+		input  Query
+		output []any
+	}{
+		{RangeStep(0, 10, 3), []any{0, 3, 6, 9}},
+		{RangeStep(0, 9, 3), []any{0, 3, 6}},
+		{RangeStep(5, 0, -2), []any{5, 3, 1}},
+		{RangeStep(5, 5, 1), nil},
+		{RangeStep(5, 10, -1), nil},
+		{RangeStep(0.0, 0.35, 0.1), []any{0.0, 0.1, 0.2, 0.30000000000000004}},
+		{RangeStep(1.0, 0.0, -0.25), []any{1.0, 0.75, 0.5, 0.25}},
+		{RangeStep[int8](-100, 127, 100), []any{int8(-100), int8(0), int8(100)}},
+		{RangeStep[uint8](250, 255, 2), []any{uint8(250), uint8(252), uint8(254)}},
+		{RangeStep(time.Second, 0, -400*time.Millisecond), []any{time.Second, 600 * time.Millisecond, 200 * time.Millisecond}},
+	}
+
+	for i, test := range tests {
+		if !testQueryIteration(test.input, test.output) {
+			t.Errorf("test %d: RangeStep()=%v expected %v", i, toSlice(test.input), test.output)
+		}
+	}
+}
+
+func TestRangeStep_PanicWhenStepIsZero(t *testing.T) {
+	mustPanicWithError(t, "RangeStep: step must not be zero", func() {
+		RangeStep(0, 1, 0)
+	})
+}
+
+func TestTimeRange(t *testing.T) {
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(3 * time.Hour)
+
+	w := []any{start, start.Add(time.Hour), start.Add(2 * time.Hour)}
+	if q := TimeRange(start, end, time.Hour); !testQueryIteration(q, w) {
+		t.Errorf("TimeRange(1h)=%v expected %v", toSlice(q), w)
+	}
+
+	w = []any{end, end.Add(-90 * time.Minute)}
+	if q := TimeRange(end, start, -90*time.Minute); !testQueryIteration(q, w) {
+		t.Errorf("TimeRange(-90m)=%v expected %v", toSlice(q), w)
+	}
+
+	mustPanicWithError(t, "TimeRange: step must not be zero", func() {
+		TimeRange(start, end, 0)
+	})
+}
+
+func TestGenerate(t *testing.T) {
+	w := []any{1, 2, 4, 8, 16}
+
+	if q := Generate(1, func(i int) int { return i * 2 }).Take(5); !testQueryIteration(q, w) {
+		t.Errorf("Generate(1, double).Take(5)=%v expected %v", toSlice(q), w)
+	}
+}
+
+func TestUnfold(t *testing.T) {
+	type pair struct{ a, b int }
+
+	fibonacci := Unfold(pair{0, 1}, func(p pair) (int, pair, bool) {
+		return p.a, pair{p.b, p.a + p.b}, p.a < 20
+	})
+
+	w := []any{0, 1, 1, 2, 3, 5, 8, 13}
+	if !testQueryIteration(fibonacci, w) {
+		t.Errorf("Unfold(fibonacci)=%v expected %v", toSlice(fibonacci), w)
+	}
+}
@@ -0,0 +1,178 @@
+package linq
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+	"sync"
+)
+
+// FSEntry is a type used to store the entries yielded by FromFS.
+type FSEntry struct {
+	// Path is the slash-separated path of the entry, which begins with the
+	// root passed to FromFS, like the paths of fs.WalkDir.
+	Path string
+	// DirEntry is the entry as read from its directory.
+	DirEntry fs.DirEntry
+	// Depth is the number of directories between the root and the entry: 0
+	// for the root itself, 1 for its children, and so on.
+	Depth int
+
+	info *fsEntryInfo
+}
+
+type fsEntryInfo struct {
+	once sync.Once
+	load func() (fs.FileInfo, error)
+	info fs.FileInfo
+	err  error
+}
+
+// Info returns the FileInfo of the entry. It is loaded on the first call, so
+// queries that only need the paths or the types of the entries don't stat
+// every file. When the entry is a symbolic link followed by FromFS, Info
+// describes the target of the link.
+func (e FSEntry) Info() (fs.FileInfo, error) {
+	e.info.once.Do(func() {
+		e.info.info, e.info.err = e.info.load()
+	})
+	return e.info.info, e.info.err
+}
+
+// FSOptions configures FromFS.
+type FSOptions struct {
+	// SkipDir, if it is not nil, is called for every directory. If it returns
+	// true, neither the directory nor its content is yielded.
+	SkipDir func(entry FSEntry) bool
+	// MaxDepth, if it is positive, is the maximum depth of the entries: the
+	// directories at that depth are yielded, but their content is not.
+	MaxDepth int
+	// FollowSymlinks walks the directories that symbolic links point to, as if
+	// they were the links themselves. Links that point to one of their own
+	// parent directories are not followed. This is only detected when the
+	// file system is backed by the operating system, e.g. with os.DirFS, so
+	// MaxDepth should be set for other file systems.
+	FollowSymlinks bool
+	// OnError, if it is not nil, is called with every error of the walk, such
+	// as a directory that cannot be read, and the walk goes on without the
+	// entry. Otherwise, the first error stops the iteration.
+	OnError func(err error)
+}
+
+// errStopWalk stops the walk of FromFS when the iteration is stopped early.
+var errStopWalk = errors.New("linq: stop walk")
+
+// FromFS initializes a linq query that walks the file tree of fsys rooted at
+// root with fs.WalkDir, and yields an FSEntry for every file and directory,
+// including root, in lexical order.
+//
+// The tree is walked as the query is iterated, and no further than needed.
+// Walk errors are handled by FSOptions.OnError, and the error that stops the
+// iteration is returned by the Err method of the query.
+func FromFS(fsys fs.FS, root string, options FSOptions) SourceQuery {
+	return newSourceQuery(func(yield func(any) bool) error {
+		w := fsWalker{fsys: fsys, options: options, yield: yield}
+		if err := w.walk(root, 0); err != errStopWalk {
+			return err
+		}
+		return nil
+	})
+}
+
+type fsWalker struct {
+	fsys    fs.FS
+	options FSOptions
+	yield   func(any) bool
+}
+
+// walk walks the tree rooted at root, whose depth is depth. Unless root is the
+// root of FromFS, it is a followed symbolic link that was already yielded.
+func (w *fsWalker) walk(root string, depth int) error {
+	return fs.WalkDir(w.fsys, root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return w.fail(err)
+		}
+		if p == root && depth > 0 {
+			return nil
+		}
+
+		entry := FSEntry{
+			Path:     p,
+			DirEntry: d,
+			Depth:    depth + pathDepth(root, p),
+			info:     &fsEntryInfo{load: d.Info},
+		}
+
+		isDir, followed := d.IsDir(), false
+		if w.options.FollowSymlinks && d.Type()&fs.ModeSymlink != 0 {
+			target, err := fs.Stat(w.fsys, p)
+			if err != nil {
+				return w.fail(err)
+			}
+
+			entry.info.load = func() (fs.FileInfo, error) { return target, nil }
+			if target.IsDir() && !w.isLoop(p, target) {
+				isDir, followed = true, true
+			}
+		}
+
+		skip := fs.SkipDir
+		if !d.IsDir() {
+			// Returning SkipDir for a file would skip the rest of its
+			// directory.
+			skip = nil
+		}
+
+		if isDir && w.options.SkipDir != nil && w.options.SkipDir(entry) {
+			return skip
+		}
+		if !w.yield(entry) {
+			return errStopWalk
+		}
+
+		if !isDir || w.options.MaxDepth > 0 && entry.Depth >= w.options.MaxDepth {
+			return skip
+		}
+		if followed {
+			return w.walk(p, entry.Depth)
+		}
+		return nil
+	})
+}
+
+// fail returns the error that ends the walk for err, if any.
+func (w *fsWalker) fail(err error) error {
+	if w.options.OnError == nil {
+		return err
+	}
+
+	w.options.OnError(err)
+	return nil
+}
+
+// isLoop reports whether the symbolic link p points to one of its parent
+// directories.
+func (w *fsWalker) isLoop(p string, target fs.FileInfo) bool {
+	for dir := path.Dir(p); ; dir = path.Dir(dir) {
+		if info, err := fs.Stat(w.fsys, dir); err == nil && os.SameFile(info, target) {
+			return true
+		}
+		if dir == "." || dir == "/" {
+			return false
+		}
+	}
+}
+
+// pathDepth returns the number of directories between root and its
+// descendant p.
+func pathDepth(root, p string) int {
+	if p == root {
+		return 0
+	}
+	if root != "." {
+		p = strings.TrimPrefix(p, strings.TrimSuffix(root, "/")+"/")
+	}
+	return strings.Count(p, "/") + 1
+}
@@ -0,0 +1,253 @@
+package linq
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+// walkFS is a file system that records the directories read by FromFS, counts
+// the FileInfo loaded from its entries, and fails to read the directories of
+// errs.
+type walkFS struct {
+	fstest.MapFS
+	errs  map[string]error
+	reads []string
+	infos int
+}
+
+func (f *walkFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	f.reads = append(f.reads, name)
+	if err := f.errs[name]; err != nil {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: err}
+	}
+
+	entries, err := f.MapFS.ReadDir(name)
+	for i, entry := range entries {
+		entries[i] = countingEntry{entry, &f.infos}
+	}
+	return entries, err
+}
+
+type countingEntry struct {
+	fs.DirEntry
+	infos *int
+}
+
+func (e countingEntry) Info() (fs.FileInfo, error) {
+	*e.infos++
+	return e.DirEntry.Info()
+}
+
+func newWalkFS() *walkFS {
+	return &walkFS{MapFS: fstest.MapFS{
+		"a/b/c.txt":  {Data: []byte("c")},
+		"a/d.txt":    {Data: []byte("dd")},
+		"e/f/g/h.go": {Data: []byte("package h")},
+		"i.txt":      {Data: []byte("iii")},
+	}}
+}
+
+// fsPaths returns the paths and depths of the entries of q.
+func fsPaths(q Query) []any {
+	var paths []any
+	for item := range q.Iterate {
+		entry := item.(FSEntry)
+		paths = append(paths, []any{entry.Path, entry.Depth})
+	}
+	return paths
+}
+
+func TestFromFS(t *testing.T) {
+	tests := []struct {
+		root    string
+		options FSOptions
+		output  []any
+	}{
+		{".", FSOptions{}, []any{
+			[]any{".", 0}, []any{"a", 1}, []any{"a/b", 2}, []any{"a/b/c.txt", 3}, []any{"a/d.txt", 2},
+			[]any{"e", 1}, []any{"e/f", 2}, []any{"e/f/g", 3}, []any{"e/f/g/h.go", 4}, []any{"i.txt", 1},
+		}},
+		{"e/f", FSOptions{}, []any{
+			[]any{"e/f", 0}, []any{"e/f/g", 1}, []any{"e/f/g/h.go", 2},
+		}},
+		{"i.txt", FSOptions{}, []any{
+			[]any{"i.txt", 0},
+		}},
+		{".", FSOptions{MaxDepth: 1}, []any{
+			[]any{".", 0}, []any{"a", 1}, []any{"e", 1}, []any{"i.txt", 1},
+		}},
+		{"a", FSOptions{MaxDepth: 1}, []any{
+			[]any{"a", 0}, []any{"a/b", 1}, []any{"a/d.txt", 1},
+		}},
+		{".", FSOptions{SkipDir: func(entry FSEntry) bool {
+			return entry.DirEntry.Name() == "b" || entry.Path == "e/f"
+		}}, []any{
+			[]any{".", 0}, []any{"a", 1}, []any{"a/d.txt", 2}, []any{"e", 1}, []any{"i.txt", 1},
+		}},
+	}
+
+	for _, test := range tests {
+		q := FromFS(newWalkFS(), test.root, test.options)
+		if out := fsPaths(q.Query); !reflect.DeepEqual(out, test.output) {
+			t.Errorf("FromFS(%q, %+v)=%v expected %v", test.root, test.options, out, test.output)
+		}
+		if err := q.Err(); err != nil {
+			t.Errorf("FromFS(%q).Err()=%v expected nil", test.root, err)
+		}
+	}
+}
+
+func TestFromFS_MaxDepthSkipsReads(t *testing.T) {
+	fsys := newWalkFS()
+	FromFS(fsys, ".", FSOptions{MaxDepth: 1}).Count()
+
+	if w := []string{"."}; !reflect.DeepEqual(fsys.reads, w) {
+		t.Errorf("FromFS(MaxDepth: 1) read %v expected %v", fsys.reads, w)
+	}
+}
+
+func TestFromFS_Info(t *testing.T) {
+	fsys := newWalkFS()
+	q := FromFS(fsys, ".", FSOptions{})
+
+	var entry FSEntry
+	for item := range q.Iterate {
+		if e := item.(FSEntry); e.Path == "a/d.txt" {
+			entry = e
+		}
+	}
+
+	if fsys.infos != 0 {
+		t.Errorf("FromFS() loaded %d FileInfo expected 0", fsys.infos)
+	}
+
+	for range 2 {
+		info, err := entry.Info()
+		if err != nil || info.Size() != 2 || info.Name() != "d.txt" {
+			t.Errorf("FSEntry.Info()=%v,%v expected d.txt of size 2", info, err)
+		}
+	}
+	if fsys.infos != 1 {
+		t.Errorf("FSEntry.Info() loaded %d FileInfo expected 1", fsys.infos)
+	}
+}
+
+func TestFromFS_Errors(t *testing.T) {
+	q := FromFS(newWalkFS(), "missing", FSOptions{})
+	if out := fsPaths(q.Query); len(out) != 0 {
+		t.Errorf("FromFS(missing)=%v expected []", out)
+	}
+	if err := q.Err(); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("FromFS(missing).Err()=%v expected %v", err, fs.ErrNotExist)
+	}
+
+	errDenied := errors.New("denied")
+	fsys := newWalkFS()
+	fsys.errs = map[string]error{"e/f": errDenied}
+
+	q = FromFS(fsys, ".", FSOptions{})
+	want := []any{
+		[]any{".", 0}, []any{"a", 1}, []any{"a/b", 2}, []any{"a/b/c.txt", 3}, []any{"a/d.txt", 2},
+		[]any{"e", 1}, []any{"e/f", 2},
+	}
+	if out := fsPaths(q.Query); !reflect.DeepEqual(out, want) {
+		t.Errorf("FromFS()=%v expected %v", out, want)
+	}
+	if err := q.Err(); !errors.Is(err, errDenied) {
+		t.Errorf("FromFS().Err()=%v expected %v", err, errDenied)
+	}
+
+	var errs []error
+	q = FromFS(fsys, ".", FSOptions{OnError: func(err error) { errs = append(errs, err) }})
+	want = append(want, []any{"i.txt", 1})
+	if out := fsPaths(q.Query); !reflect.DeepEqual(out, want) {
+		t.Errorf("FromFS(OnError)=%v expected %v", out, want)
+	}
+	if err := q.Err(); err != nil {
+		t.Errorf("FromFS(OnError).Err()=%v expected nil", err)
+	}
+	if len(errs) != 1 || !errors.Is(errs[0], errDenied) {
+		t.Errorf("FromFS(OnError) reported %v expected [%v]", errs, errDenied)
+	}
+}
+
+func TestFromFS_EarlyStop(t *testing.T) {
+	fsys := newWalkFS()
+	q := FromFS(fsys, ".", FSOptions{})
+
+	var paths []string
+	for item := range q.Iterate {
+		paths = append(paths, item.(FSEntry).Path)
+		if len(paths) == 3 {
+			break
+		}
+	}
+
+	if w := []string{".", "a", "a/b"}; !reflect.DeepEqual(paths, w) {
+		t.Errorf("FromFS()=%v expected %v", paths, w)
+	}
+	if w := []string{".", "a"}; !reflect.DeepEqual(fsys.reads, w) {
+		t.Errorf("FromFS() read %v expected %v", fsys.reads, w)
+	}
+	if err := q.Err(); err != nil {
+		t.Errorf("FromFS().Err()=%v expected nil", err)
+	}
+}
+
+func TestFromFS_Symlinks(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"data/sub", "root"} {
+		if err := os.MkdirAll(filepath.Join(dir, name), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "data/sub/x.txt"), []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(dir, "data"), filepath.Join(dir, "root/data")); err != nil {
+		t.Skipf("symbolic links are not supported: %v", err)
+	}
+	if err := os.Symlink(filepath.Join(dir, "root"), filepath.Join(dir, "root/loop")); err != nil {
+		t.Fatal(err)
+	}
+	fsys := os.DirFS(dir)
+
+	q := FromFS(fsys, "root", FSOptions{})
+	want := []any{
+		[]any{"root", 0}, []any{"root/data", 1}, []any{"root/loop", 1},
+	}
+	if out := fsPaths(q.Query); !reflect.DeepEqual(out, want) {
+		t.Errorf("FromFS()=%v expected %v", out, want)
+	}
+
+	q = FromFS(fsys, "root", FSOptions{FollowSymlinks: true})
+	want = []any{
+		[]any{"root", 0}, []any{"root/data", 1}, []any{"root/data/sub", 2}, []any{"root/data/sub/x.txt", 3},
+		[]any{"root/loop", 1},
+	}
+	if out := fsPaths(q.Query); !reflect.DeepEqual(out, want) {
+		t.Errorf("FromFS(FollowSymlinks)=%v expected %v", out, want)
+	}
+	if err := q.Err(); err != nil {
+		t.Errorf("FromFS(FollowSymlinks).Err()=%v expected nil", err)
+	}
+
+	q = FromFS(fsys, "root", FSOptions{FollowSymlinks: true, MaxDepth: 2})
+	want = []any{
+		[]any{"root", 0}, []any{"root/data", 1}, []any{"root/data/sub", 2}, []any{"root/loop", 1},
+	}
+	if out := fsPaths(q.Query); !reflect.DeepEqual(out, want) {
+		t.Errorf("FromFS(FollowSymlinks, MaxDepth: 2)=%v expected %v", out, want)
+	}
+
+	link := FromFS(fsys, "root", FSOptions{FollowSymlinks: true}).
+		FirstWith(func(item any) bool { return item.(FSEntry).Path == "root/data" }).(FSEntry)
+	if info, err := link.Info(); err != nil || !info.IsDir() {
+		t.Errorf("FSEntry.Info()=%v,%v expected the directory of the link", info, err)
+	}
+}
@@ -0,0 +1,120 @@
+package linq
+
+// GroupAdjacent groups the adjacent elements of a collection that have the
+// same key, as returned by a specified key selector function, and projects the
+// elements of each group by using a specified function.
+//
+// Unlike GroupBy, GroupAdjacent yields a Group each time the key changes, so
+// the same key can be yielded several times, e.g. the keys of 1, 1, 2, 1 are
+// grouped as [1 1], [2] and [1]. The groups are yielded as soon as they end,
+// and only the elements of the current group are kept in memory.
+func (q Query) GroupAdjacent(keySelector func(any) any,
+	elementSelector func(any) any) Query {
+	return Query{
+		Iterate: func(yield func(any) bool) {
+			var group *Group
+
+			for item := range q.Iterate {
+				key := keySelector(item)
+				if group != nil && key != group.Key {
+					if !yield(*group) {
+						return
+					}
+					group = nil
+				}
+
+				if group == nil {
+					group = &Group{Key: key}
+				}
+				group.Group = append(group.Group, elementSelector(item))
+			}
+
+			if group != nil {
+				yield(*group)
+			}
+		},
+	}
+}
+
+// GroupAdjacentT is the typed version of GroupAdjacent.
+//
+//   - keySelectorFn is of type "func(TSource) TKey"
+//   - elementSelectorFn is of type "func(TSource) TElement"
+//
+// NOTE: GroupAdjacent has better performance than GroupAdjacentT.
+func (q Query) GroupAdjacentT(keySelectorFn any,
+	elementSelectorFn any) Query {
+	keySelectorGenericFunc, err := newGenericFunc(
+		"GroupAdjacentT", "keySelectorFn", keySelectorFn,
+		simpleParamValidator(newElemTypeSlice(new(genericType)), newElemTypeSlice(new(genericType))),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	keySelectorFunc := func(item any) any {
+		return keySelectorGenericFunc.Call(item)
+	}
+
+	elementSelectorGenericFunc, err := newGenericFunc(
+		"GroupAdjacentT", "elementSelectorFn", elementSelectorFn,
+		simpleParamValidator(newElemTypeSlice(new(genericType)), newElemTypeSlice(new(genericType))),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	elementSelectorFunc := func(item any) any {
+		return elementSelectorGenericFunc.Call(item)
+	}
+
+	return q.GroupAdjacent(keySelectorFunc, elementSelectorFunc)
+}
+
+// DistinctUntilChanged returns the elements of a collection whose key, as
+// returned by a specified key selector function, differs from the key of the
+// element before it. In other words, it keeps the first element of every run
+// of adjacent elements with the same key.
+//
+// Unlike DistinctBy, DistinctUntilChanged only remembers the last key, so an
+// element is yielded again when its key comes back after a different one.
+func (q Query) DistinctUntilChanged(keySelector func(any) any) Query {
+	return Query{
+		Iterate: func(yield func(any) bool) {
+			var previous any
+			isFirst := true
+
+			for item := range q.Iterate {
+				key := keySelector(item)
+				if isFirst || key != previous {
+					previous, isFirst = key, false
+					if !yield(item) {
+						return
+					}
+				}
+			}
+		},
+	}
+}
+
+// DistinctUntilChangedT is the typed version of DistinctUntilChanged.
+//
+//   - keySelectorFn is of type "func(TSource) TKey"
+//
+// NOTE: DistinctUntilChanged has better performance than
+// DistinctUntilChangedT.
+func (q Query) DistinctUntilChangedT(keySelectorFn any) Query {
+	keySelectorGenericFunc, err := newGenericFunc(
+		"DistinctUntilChangedT", "keySelectorFn", keySelectorFn,
+		simpleParamValidator(newElemTypeSlice(new(genericType)), newElemTypeSlice(new(genericType))),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	keySelectorFunc := func(item any) any {
+		return keySelectorGenericFunc.Call(item)
+	}
+
+	return q.DistinctUntilChanged(keySelectorFunc)
+}
@@ -0,0 +1,78 @@
+package linq
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGroupAdjacent(t *testing.T) {
+	tests := []struct {
+		input  []int
+		output []any
+	}{
+		{[]int{}, nil},
+		{[]int{7}, []any{Group{Key: true, Group: []any{7}}}},
+		{[]int{1, 3, 2, 4, 6, 5, 8}, []any{
+			Group{Key: true, Group: []any{1, 3}},
+			Group{Key: false, Group: []any{2, 4, 6}},
+			Group{Key: true, Group: []any{5}},
+			Group{Key: false, Group: []any{8}},
+		}},
+	}
+
+	for _, test := range tests {
+		q := From(test.input).GroupAdjacentT(
+			func(i int) bool { return i%2 == 1 },
+			func(i int) int { return i },
+		)
+		runDryIteration(q)
+		if got := q.Results(); !reflect.DeepEqual(got, test.output) {
+			t.Errorf("From(%v).GroupAdjacentT()=%v expected %v", test.input, got, test.output)
+		}
+	}
+}
+
+func TestGroupAdjacentIsLazy(t *testing.T) {
+	read := 0
+	input := Range(1, 100).Select(func(i any) any {
+		read++
+		return i.(int) / 3
+	})
+
+	var got []any
+	for group := range input.GroupAdjacent(identity, identity).Iterate {
+		if got = append(got, group); len(got) == 2 {
+			break
+		}
+	}
+
+	want := []any{Group{Key: 0, Group: []any{0, 0}}, Group{Key: 1, Group: []any{1, 1, 1}}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GroupAdjacent()=%v expected %v", got, want)
+	}
+	if read != 6 {
+		t.Errorf("GroupAdjacent() read %d elements for 2 groups, expected 6", read)
+	}
+}
+
+func TestGroupAdjacentT_PanicWhenElementSelectorFnIsInvalid(t *testing.T) {
+	mustPanicWithError(t, "GroupAdjacentT: parameter [elementSelectorFn] has a invalid function signature. Expected: 'func(T)T', actual: 'func(int,int)int'", func() {
+		From([]int{1}).GroupAdjacentT(func(i int) int { return i }, func(i, j int) int { return i })
+	})
+}
+
+func TestDistinctUntilChanged(t *testing.T) {
+	input := []string{"a", "A", "b", "a", "a", "B", "b", "c"}
+	want := []any{"a", "b", "a", "B", "c"}
+
+	q := From(input).DistinctUntilChangedT(func(s string) byte { return s[0] | 0x20 })
+	if !testQueryIteration(q, want) {
+		t.Errorf("From(%v).DistinctUntilChangedT()=%v expected %v", input, toSlice(q), want)
+	}
+}
+
+func TestDistinctUntilChangedT_PanicWhenKeySelectorFnIsInvalid(t *testing.T) {
+	mustPanicWithError(t, "DistinctUntilChangedT: parameter [keySelectorFn] has a invalid function signature. Expected: 'func(T)T', actual: 'func(int,int)int'", func() {
+		From([]int{1}).DistinctUntilChangedT(func(i, j int) int { return i })
+	})
+}
@@ -0,0 +1,186 @@
+package linq
+
+import (
+	"math"
+	"sort"
+)
+
+// HistogramBucket is a type used to store the buckets returned by Histogram.
+// A bucket counts the values v such that Lower <= v < Upper.
+type HistogramBucket struct {
+	Lower float64
+	Upper float64
+	Count int
+}
+
+// Histogram counts the numeric values of a collection in the buckets delimited
+// by edges, which have to be strictly increasing.
+//
+// The result has len(edges)+1 buckets: the first one counts the values below
+// the first edge and has a Lower of math.Inf(-1), and the last one counts the
+// values at or above the last edge and has an Upper of math.Inf(1). NaN values
+// are not counted.
+//
+// Values can be of any integer, unsigned integer or float type. To count
+// values in buckets computed by a function, use HistogramFunc.
+func (q Query) Histogram(edges ...float64) []HistogramBucket {
+	return q.HistogramBy(identity, edges...)
+}
+
+// HistogramBy counts the numeric values obtained by invoking a transform
+// function on each element of a collection in the buckets delimited by edges,
+// in the same way as Histogram.
+func (q Query) HistogramBy(selector func(any) any,
+	edges ...float64) []HistogramBucket {
+	for i := 1; i < len(edges); i++ {
+		if !(edges[i-1] < edges[i]) {
+			panic("Histogram: edges must be strictly increasing")
+		}
+	}
+
+	buckets := make([]HistogramBucket, len(edges)+1)
+	for i := range buckets {
+		buckets[i].Lower, buckets[i].Upper = math.Inf(-1), math.Inf(1)
+		if i > 0 {
+			buckets[i].Lower = edges[i-1]
+		}
+		if i < len(edges) {
+			buckets[i].Upper = edges[i]
+		}
+	}
+
+	var conv floatConverter
+	for item := range q.Iterate {
+		value := selector(item)
+		if conv == nil {
+			conv = getNumberConverter(value)
+		}
+
+		x := conv(value)
+		if math.IsNaN(x) {
+			continue
+		}
+
+		i := sort.Search(len(edges), func(i int) bool { return edges[i] > x })
+		buckets[i].Count++
+	}
+
+	return buckets
+}
+
+// HistogramByT is the typed version of HistogramBy.
+//
+//   - selectorFn is of type "func(TSource) TNumber"
+//
+// NOTE: HistogramBy has better performance than HistogramByT.
+func (q Query) HistogramByT(selectorFn any,
+	edges ...float64) []HistogramBucket {
+	selectorGenericFunc, err := newGenericFunc(
+		"HistogramByT", "selectorFn", selectorFn,
+		simpleParamValidator(newElemTypeSlice(new(genericType)), newElemTypeSlice(new(genericType))),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	selectorFunc := func(item any) any {
+		return selectorGenericFunc.Call(item)
+	}
+
+	return q.HistogramBy(selectorFunc, edges...)
+}
+
+// HistogramCounts is a type used to store the counts returned by
+// HistogramFunc.
+type HistogramCounts struct {
+	// Buckets holds the count of each bucket, by index.
+	Buckets []int
+	// Underflow counts the values whose bucket index is negative.
+	Underflow int
+	// Overflow counts the values whose bucket index is len(Buckets) or more.
+	Overflow int
+}
+
+// HistogramFunc counts the numeric values of a collection in count buckets,
+// where bucketFn returns the index of the bucket of a value, e.g.
+// int(math.Floor(x/10)) for buckets of width 10 starting at zero.
+//
+// The values whose index is negative are counted in the underflow bucket, and
+// the values whose index is count or more in the overflow bucket. Infinite
+// values are counted in the underflow or overflow bucket, and NaN values are
+// not counted: bucketFn is not called for them, since their conversion to an
+// index is not defined.
+//
+// Values can be of any integer, unsigned integer or float type. HistogramFunc
+// panics if count is negative.
+func (q Query) HistogramFunc(count int, bucketFn func(float64) int) HistogramCounts {
+	return q.HistogramFuncBy(identity, count, bucketFn)
+}
+
+// HistogramFuncBy counts the numeric values obtained by invoking a transform
+// function on each element of a collection in the buckets chosen by bucketFn,
+// in the same way as HistogramFunc.
+func (q Query) HistogramFuncBy(selector func(any) any, count int,
+	bucketFn func(float64) int) HistogramCounts {
+	if count < 0 {
+		panic("HistogramFunc: count must not be negative")
+	}
+
+	r := HistogramCounts{Buckets: make([]int, count)}
+
+	var conv floatConverter
+	for item := range q.Iterate {
+		value := selector(item)
+		if conv == nil {
+			conv = getNumberConverter(value)
+		}
+
+		x := conv(value)
+		if math.IsNaN(x) {
+			continue
+		}
+
+		var i int
+		switch {
+		case math.IsInf(x, -1):
+			i = -1
+		case math.IsInf(x, 1):
+			i = count
+		default:
+			i = bucketFn(x)
+		}
+
+		switch {
+		case i < 0:
+			r.Underflow++
+		case i >= count:
+			r.Overflow++
+		default:
+			r.Buckets[i]++
+		}
+	}
+
+	return r
+}
+
+// HistogramFuncByT is the typed version of HistogramFuncBy.
+//
+//   - selectorFn is of type "func(TSource) TNumber"
+//
+// NOTE: HistogramFuncBy has better performance than HistogramFuncByT.
+func (q Query) HistogramFuncByT(selectorFn any, count int,
+	bucketFn func(float64) int) HistogramCounts {
+	selectorGenericFunc, err := newGenericFunc(
+		"HistogramFuncByT", "selectorFn", selectorFn,
+		simpleParamValidator(newElemTypeSlice(new(genericType)), newElemTypeSlice(new(genericType))),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	selectorFunc := func(item any) any {
+		return selectorGenericFunc.Call(item)
+	}
+
+	return q.HistogramFuncBy(selectorFunc, count, bucketFn)
+}
@@ -0,0 +1,109 @@
+package linq
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestHistogram(t *testing.T) {
+	inf := math.Inf(1)
+
+	tests := []struct {
+		input any
+		edges []float64
+		want  []HistogramBucket
+	}{
+		{[]int{-5, 0, 3, 9, 10, 25, 100}, []float64{0, 10, 20}, []HistogramBucket{
+			{-inf, 0, 1},
+			{0, 10, 3},
+			{10, 20, 1},
+			{20, inf, 2},
+		}},
+		{[]float64{0.5, math.NaN(), 1.5}, []float64{1}, []HistogramBucket{
+			{-inf, 1, 1},
+			{1, inf, 1},
+		}},
+		{[]uint{}, nil, []HistogramBucket{{-inf, inf, 0}}},
+	}
+
+	for _, test := range tests {
+		if r := From(test.input).Histogram(test.edges...); !reflect.DeepEqual(r, test.want) {
+			t.Errorf("From(%v).Histogram(%v)=%v expected %v", test.input, test.edges, r, test.want)
+		}
+	}
+}
+
+func TestHistogramBy(t *testing.T) {
+	input := []foo{{f1: 1}, {f1: 15}, {f1: 12}}
+	want := []HistogramBucket{{math.Inf(-1), 10, 1}, {10, math.Inf(1), 2}}
+
+	if r := From(input).HistogramBy(func(i any) any { return i.(foo).f1 }, 10); !reflect.DeepEqual(r, want) {
+		t.Errorf("From(%v).HistogramBy()=%v expected %v", input, r, want)
+	}
+	if r := From(input).HistogramByT(func(f foo) int { return f.f1 }, 10); !reflect.DeepEqual(r, want) {
+		t.Errorf("From(%v).HistogramByT()=%v expected %v", input, r, want)
+	}
+}
+
+func TestHistogram_PanicWhenEdgesAreNotIncreasing(t *testing.T) {
+	mustPanicWithError(t, "Histogram: edges must be strictly increasing", func() {
+		From([]int{1}).Histogram(1, 3, 3)
+	})
+}
+
+func TestHistogramByT_PanicWhenSelectorFnIsInvalid(t *testing.T) {
+	mustPanicWithError(t, "HistogramByT: parameter [selectorFn] has a invalid function signature. Expected: 'func(T)T', actual: 'func(int,int)int'", func() {
+		From([]int{1, 2}).HistogramByT(func(i, j int) int { return i }, 1)
+	})
+}
+
+func TestHistogramFunc(t *testing.T) {
+	byTens := func(x float64) int { return int(math.Floor(x / 10)) }
+
+	tests := []struct {
+		input any
+		count int
+		want  HistogramCounts
+	}{
+		{[]int{-5, 0, 3, 9, 10, 25, 29, 30, 100}, 3, HistogramCounts{Buckets: []int{3, 1, 2}, Underflow: 1, Overflow: 2}},
+		{[]float64{math.NaN(), 5, math.Inf(1), math.Inf(-1)}, 1, HistogramCounts{Buckets: []int{1}, Underflow: 1, Overflow: 1}},
+		{[]uint{1, 2}, 0, HistogramCounts{Buckets: []int{}, Overflow: 2}},
+	}
+
+	for _, test := range tests {
+		if r := From(test.input).HistogramFunc(test.count, byTens); !reflect.DeepEqual(r, test.want) {
+			t.Errorf("From(%v).HistogramFunc(%d)=%+v expected %+v", test.input, test.count, r, test.want)
+		}
+	}
+}
+
+func TestHistogramFuncBy(t *testing.T) {
+	input := []foo{{f1: 1}, {f1: 15}, {f1: 12}, {f1: -1}}
+	isTeen := func(x float64) int {
+		if x < 13 {
+			return -1
+		}
+		return 0
+	}
+	want := HistogramCounts{Buckets: []int{1}, Underflow: 3}
+
+	if r := From(input).HistogramFuncBy(func(i any) any { return i.(foo).f1 }, 1, isTeen); !reflect.DeepEqual(r, want) {
+		t.Errorf("From(%v).HistogramFuncBy()=%+v expected %+v", input, r, want)
+	}
+	if r := From(input).HistogramFuncByT(func(f foo) int { return f.f1 }, 1, isTeen); !reflect.DeepEqual(r, want) {
+		t.Errorf("From(%v).HistogramFuncByT()=%+v expected %+v", input, r, want)
+	}
+}
+
+func TestHistogramFunc_PanicWhenCountIsNegative(t *testing.T) {
+	mustPanicWithError(t, "HistogramFunc: count must not be negative", func() {
+		From([]int{1}).HistogramFunc(-1, func(x float64) int { return 0 })
+	})
+}
+
+func TestHistogramFuncByT_PanicWhenSelectorFnIsInvalid(t *testing.T) {
+	mustPanicWithError(t, "HistogramFuncByT: parameter [selectorFn] has a invalid function signature. Expected: 'func(T)T', actual: 'func(int,int)int'", func() {
+		From([]int{1, 2}).HistogramFuncByT(func(i, j int) int { return i }, 1, func(x float64) int { return 0 })
+	})
+}
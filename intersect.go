@@ -75,3 +75,64 @@ func (q Query) IntersectByT(q2 Query,
 
 	return q.IntersectBy(q2, selectorFunc)
 }
+
+// IntersectAll produces the multiset intersection of the source collection and
+// the provided input collection. Unlike Intersect, it respects the multiplicity
+// of the elements: an element that appears m times in the source collection
+// and n times in the input collection is returned min(m, n) times, like SQL's
+// INTERSECT ALL.
+//
+// IntersectAll preserves the order of the elements of the source collection.
+func (q Query) IntersectAll(q2 Query) Query {
+	return q.IntersectAllBy(q2, func(item any) any { return item })
+}
+
+// IntersectAllBy produces the multiset intersection of the source collection
+// and the provided input collection.
+//
+// IntersectAllBy invokes a transform function on each element of both
+// collections.
+func (q Query) IntersectAllBy(q2 Query,
+	selector func(any) any) Query {
+
+	return Query{
+		Iterate: func(yield func(any) bool) {
+			counts := make(map[any]int)
+			for item := range q2.Iterate {
+				counts[selector(item)]++
+			}
+
+			for item := range q.Iterate {
+				key := selector(item)
+				if counts[key] > 0 {
+					counts[key]--
+					if !yield(item) {
+						return
+					}
+				}
+			}
+		},
+	}
+}
+
+// IntersectAllByT is the typed version of IntersectAllBy.
+//
+//   - selectorFn is of type "func(TSource) TSource"
+//
+// NOTE: IntersectAllBy has better performance than IntersectAllByT.
+func (q Query) IntersectAllByT(q2 Query,
+	selectorFn any) Query {
+	selectorGenericFunc, err := newGenericFunc(
+		"IntersectAllByT", "selectorFn", selectorFn,
+		simpleParamValidator(newElemTypeSlice(new(genericType)), newElemTypeSlice(new(genericType))),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	selectorFunc := func(item any) any {
+		return selectorGenericFunc.Call(item)
+	}
+
+	return q.IntersectAllBy(q2, selectorFunc)
+}
@@ -31,3 +31,33 @@ func TestIntersectByT_PanicWhenSelectorFnIsInvalid(t *testing.T) {
 		})
 	})
 }
+
+func TestIntersectAll(t *testing.T) {
+	input1 := []int{1, 1, 1, 2, 3, 3}
+	input2 := []int{3, 1, 4, 1, 3, 3}
+	want := []any{1, 1, 3, 3}
+
+	if q := From(input1).IntersectAll(From(input2)); !testQueryIteration(q, want) {
+		t.Errorf("From(%v).IntersectAll(%v)=%v expected %v", input1, input2, toSlice(q), want)
+	}
+}
+
+func TestIntersectAllBy(t *testing.T) {
+	input1 := []int{5, 7, 8, 9, 10}
+	input2 := []int{1, 3, 4}
+	want := []any{5, 7, 8}
+
+	if q := From(input1).IntersectAllBy(From(input2), func(i any) any {
+		return i.(int) % 2
+	}); !testQueryIteration(q, want) {
+		t.Errorf("From(%v).IntersectAllBy(%v)=%v expected %v", input1, input2, toSlice(q), want)
+	}
+}
+
+func TestIntersectAllByT_PanicWhenSelectorFnIsInvalid(t *testing.T) {
+	mustPanicWithError(t, "IntersectAllByT: parameter [selectorFn] has a invalid function signature. Expected: 'func(T)T', actual: 'func(int,int)int'", func() {
+		From([]int{5, 7, 8}).IntersectAllByT(From([]int{1, 4, 7, 9, 12, 3}), func(i, x int) int {
+			return i % 2
+		})
+	})
+}
@@ -0,0 +1,187 @@
+package linq
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// JSONError is the error of an element of a FromJSONLines or FromJSONArray
+// query that cannot be decoded.
+type JSONError struct {
+	// Index is the 0-based index of the element in the stream or the array.
+	Index int
+	// Offset is the offset in bytes of the input right after the element
+	// before it, where the decoding of the element starts. The syntax and
+	// type errors of encoding/json in Err hold the offset of the error itself.
+	Offset int64
+	Err    error
+}
+
+func (e *JSONError) Error() string {
+	return fmt.Sprintf("json: element %d at offset %d: %v", e.Index, e.Offset, e.Err)
+}
+
+func (e *JSONError) Unwrap() error {
+	return e.Err
+}
+
+// FromJSONLines initializes a linq query that decodes the stream of JSON
+// values of r, such as a JSON Lines or NDJSON file, one at a time with
+// encoding/json, and yields them as values of type T, e.g. a struct type or
+// map[string]any.
+//
+// Only the element being decoded is kept in memory. The first element that
+// cannot be decoded stops the iteration, and its *JSONError is returned by
+// the Err method of the query.
+func FromJSONLines[T any](r io.Reader) SourceQuery {
+	return newSourceQuery(func(yield func(any) bool) error {
+		dec := json.NewDecoder(r)
+
+		for index := 0; ; index++ {
+			offset := dec.InputOffset()
+
+			var item T
+			if err := dec.Decode(&item); err == io.EOF {
+				return nil
+			} else if err != nil {
+				return &JSONError{Index: index, Offset: offset, Err: err}
+			}
+
+			if !yield(item) {
+				return nil
+			}
+		}
+	})
+}
+
+// FromJSONArray initializes a linq query that decodes the elements of the JSON
+// array of r one at a time with encoding/json, and yields them as values of
+// type T, e.g. a struct type or map[string]any.
+//
+// The array is read with json.Decoder.Token and json.Decoder.More, so only the
+// element being decoded is kept in memory, however large the array is. The
+// first element that cannot be decoded, or an input that is not an array,
+// stops the iteration, and the *JSONError is returned by the Err method of
+// the query.
+func FromJSONArray[T any](r io.Reader) SourceQuery {
+	return newSourceQuery(func(yield func(any) bool) error {
+		dec := json.NewDecoder(r)
+
+		tok, err := dec.Token()
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return &JSONError{Err: err}
+		}
+		if tok != json.Delim('[') {
+			return &JSONError{Err: fmt.Errorf("expected an array, got %v", tok)}
+		}
+
+		index := 0
+		for ; dec.More(); index++ {
+			offset := dec.InputOffset()
+
+			var item T
+			if err := dec.Decode(&item); err != nil {
+				return &JSONError{Index: index, Offset: offset, Err: err}
+			}
+
+			if !yield(item) {
+				return nil
+			}
+		}
+
+		// Read the end of the array, which More doesn't consume.
+		offset := dec.InputOffset()
+		if _, err := dec.Token(); err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return &JSONError{Index: index, Offset: offset, Err: err}
+		}
+
+		return nil
+	})
+}
+
+// JSONOptions configures ToJSONArray.
+type JSONOptions struct {
+	// Prefix and Indent indent the array like json.MarshalIndent: each
+	// element starts on a new line that begins with Prefix followed by one
+	// copy of Indent per nesting level. If both are empty, the array is
+	// written on a single line.
+	Prefix string
+	Indent string
+}
+
+// ToJSONArray writes the elements of a collection to w as a JSON array,
+// followed by a newline. The elements are encoded with encoding/json and
+// written one at a time as the collection is iterated, so the array is never
+// held in memory.
+//
+// ToJSONArray stops at the first error, such as an element that cannot be
+// encoded or a write error, and returns it. The array written so far is then
+// incomplete.
+func (q Query) ToJSONArray(w io.Writer, options JSONOptions) error {
+	indented := options.Prefix != "" || options.Indent != ""
+	elementPrefix := options.Prefix + options.Indent
+
+	empty := true
+	for item := range q.Iterate {
+		var b []byte
+		var err error
+		if indented {
+			b, err = json.MarshalIndent(item, elementPrefix, options.Indent)
+		} else {
+			b, err = json.Marshal(item)
+		}
+		if err != nil {
+			return err
+		}
+
+		separator := ","
+		if empty {
+			separator, empty = "[", false
+		}
+		if indented {
+			separator += "\n" + elementPrefix
+		}
+
+		if _, err := io.WriteString(w, separator); err != nil {
+			return err
+		}
+		if _, err := w.Write(b); err != nil {
+			return err
+		}
+	}
+
+	end := "]\n"
+	switch {
+	case empty:
+		end = "[]\n"
+	case indented:
+		end = "\n" + options.Prefix + end
+	}
+
+	_, err := io.WriteString(w, end)
+	return err
+}
+
+// ToJSONLines writes the elements of a collection to w as JSON Lines: each
+// element is encoded with encoding/json on a line of its own. The elements are
+// written one at a time as the collection is iterated.
+//
+// ToJSONLines stops at the first error, such as an element that cannot be
+// encoded or a write error, and returns it.
+func (q Query) ToJSONLines(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	for item := range q.Iterate {
+		if err := enc.Encode(item); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,206 @@
+package linq
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+type jsonEvent struct {
+	ID   int    `json:"id"`
+	Kind string `json:"kind"`
+}
+
+func TestFromJSONLines(t *testing.T) {
+	input := `{"id": 1, "kind": "click"}
+{"id": 2, "kind": "view", "extra": true}
+
+{"id": 3}
+`
+	want := []any{jsonEvent{1, "click"}, jsonEvent{2, "view"}, jsonEvent{ID: 3}}
+
+	q := FromJSONLines[jsonEvent](strings.NewReader(input))
+	if got := q.Results(); !reflect.DeepEqual(got, want) || q.Err() != nil {
+		t.Errorf("FromJSONLines[jsonEvent]()=%v, %v expected %v", got, q.Err(), want)
+	}
+
+	maps := FromJSONLines[map[string]any](strings.NewReader(`{"a": 1} {"b": [true]}`))
+	wantMaps := []any{map[string]any{"a": 1.0}, map[string]any{"b": []any{true}}}
+	if got := maps.Results(); !reflect.DeepEqual(got, wantMaps) || maps.Err() != nil {
+		t.Errorf("FromJSONLines[map[string]any]()=%v, %v expected %v", got, maps.Err(), wantMaps)
+	}
+}
+
+func TestFromJSONLines_Errors(t *testing.T) {
+	input := "{\"id\": 1}\n{\"id\": \"two\"}\n{\"id\": 3}\n"
+
+	q := FromJSONLines[jsonEvent](strings.NewReader(input))
+	if got := q.Results(); !reflect.DeepEqual(got, []any{jsonEvent{ID: 1}}) {
+		t.Errorf("FromJSONLines[jsonEvent]()=%v expected [{1 }]", got)
+	}
+
+	var jsonErr *JSONError
+	var typeErr *json.UnmarshalTypeError
+	if err := q.Err(); !errors.As(err, &jsonErr) || jsonErr.Index != 1 || jsonErr.Offset != 9 || !errors.As(err, &typeErr) {
+		t.Errorf("FromJSONLines[jsonEvent]().Err()=%v expected a type error of element 1 at offset 9", err)
+	}
+
+	q = FromJSONLines[jsonEvent](strings.NewReader(`{"id": 1} {"id": `))
+	if q.Results(); !errors.Is(q.Err(), io.ErrUnexpectedEOF) {
+		t.Errorf("FromJSONLines[jsonEvent]().Err()=%v expected %v", q.Err(), io.ErrUnexpectedEOF)
+	}
+}
+
+func TestFromJSONArray(t *testing.T) {
+	tests := []struct {
+		input  string
+		output []any
+	}{
+		{`[]`, nil},
+		{` [ {"id": 1, "kind": "a"} , {"id": 2} ] `, []any{jsonEvent{1, "a"}, jsonEvent{ID: 2}}},
+	}
+
+	for _, test := range tests {
+		q := FromJSONArray[jsonEvent](strings.NewReader(test.input))
+		if got := q.Results(); !reflect.DeepEqual(got, test.output) || q.Err() != nil {
+			t.Errorf("FromJSONArray[jsonEvent](%s)=%v, %v expected %v", test.input, got, q.Err(), test.output)
+		}
+	}
+}
+
+func TestFromJSONArray_Errors(t *testing.T) {
+	tests := []struct {
+		input  string
+		index  int
+		offset int64
+		err    string
+	}{
+		{``, 0, 0, "json: element 0 at offset 0: unexpected EOF"},
+		{`{"id": 1}`, 0, 0, "json: element 0 at offset 0: expected an array, got {"},
+		{`[{"id": 1}, {"id": true}]`, 1, 10, "json: element 1 at offset 10: json: cannot unmarshal bool into Go struct field jsonEvent.id of type int"},
+		{`[{"id": 1}, {"id" 2}]`, 1, 10, "json: element 1 at offset 10: invalid character '2' after object key"},
+		{`[{"id": 1}`, 1, 10, "json: element 1 at offset 10: unexpected end of JSON input"},
+	}
+
+	for _, test := range tests {
+		q := FromJSONArray[jsonEvent](strings.NewReader(test.input))
+		q.Results()
+
+		var jsonErr *JSONError
+		if err := q.Err(); !errors.As(err, &jsonErr) || jsonErr.Index != test.index || jsonErr.Offset != test.offset || err.Error() != test.err {
+			t.Errorf("FromJSONArray[jsonEvent](%s).Err()=%v expected %s", test.input, err, test.err)
+		}
+	}
+}
+
+func TestFromJSONArray_IsStreamed(t *testing.T) {
+	// The third element is never read, so the error of the reader is not
+	// returned.
+	r := io.MultiReader(strings.NewReader(`[{"id": 1}, {"id": 2}, `), iotest.ErrReader(errors.New("not read")))
+	q := FromJSONArray[jsonEvent](iotest.OneByteReader(r))
+
+	var got []any
+	for item := range q.Iterate {
+		if got = append(got, item); len(got) == 2 {
+			break
+		}
+	}
+
+	if want := []any{jsonEvent{ID: 1}, jsonEvent{ID: 2}}; !reflect.DeepEqual(got, want) || q.Err() != nil {
+		t.Errorf("FromJSONArray[jsonEvent]()=%v, %v expected %v", got, q.Err(), want)
+	}
+}
+
+// failingWriter fails once it has written n bytes.
+type failingWriter struct {
+	n   int
+	err error
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if len(p) > w.n {
+		written := w.n
+		w.n = 0
+		return written, w.err
+	}
+	w.n -= len(p)
+	return len(p), nil
+}
+
+func TestToJSONArray(t *testing.T) {
+	inputs := [][]any{
+		{},
+		{1},
+		{jsonEvent{1, "a"}, map[string]any{"b": []int{1, 2}, "c": map[string]any{}}, nil, "<s>"},
+	}
+	options := []JSONOptions{{}, {Indent: "  "}, {Prefix: "> ", Indent: "\t"}, {Prefix: "#"}}
+
+	for _, input := range inputs {
+		for _, opts := range options {
+			var want []byte
+			if opts.Prefix == "" && opts.Indent == "" {
+				want, _ = json.Marshal(input)
+			} else {
+				want, _ = json.MarshalIndent(input, opts.Prefix, opts.Indent)
+			}
+
+			var b strings.Builder
+			if err := From(input).ToJSONArray(&b, opts); err != nil || b.String() != string(want)+"\n" {
+				t.Errorf("From(%v).ToJSONArray(%+v)=%q, %v expected %q", input, opts, b.String(), err, string(want)+"\n")
+			}
+		}
+	}
+}
+
+func TestToJSONArray_Errors(t *testing.T) {
+	var b strings.Builder
+	if err := From([]any{1, func() {}}).ToJSONArray(&b, JSONOptions{}); err == nil {
+		t.Errorf("ToJSONArray(func) expected an error")
+	}
+
+	errWrite := errors.New("write failed")
+	read := 0
+	input := Range(1, 100).Select(func(i any) any {
+		read++
+		return i
+	})
+
+	if err := input.ToJSONArray(&failingWriter{n: 6, err: errWrite}, JSONOptions{}); err != errWrite {
+		t.Errorf("ToJSONArray()=%v expected %v", err, errWrite)
+	}
+	if read != 4 {
+		t.Errorf("ToJSONArray() read %d elements after a write error, expected 4", read)
+	}
+}
+
+func TestToJSONLines(t *testing.T) {
+	input := []any{jsonEvent{1, "a"}, []int{1, 2}, "x", nil}
+	want := "{\"id\":1,\"kind\":\"a\"}\n[1,2]\n\"x\"\nnull\n"
+
+	var b strings.Builder
+	if err := From(input).ToJSONLines(&b); err != nil || b.String() != want {
+		t.Errorf("From(%v).ToJSONLines()=%q, %v expected %q", input, b.String(), err, want)
+	}
+
+	// The lines can be read back.
+	q := FromJSONLines[any](strings.NewReader(b.String()))
+	if got := q.Count(); got != len(input) || q.Err() != nil {
+		t.Errorf("FromJSONLines(ToJSONLines()).Count()=%d, %v expected %d", got, q.Err(), len(input))
+	}
+}
+
+func TestToJSONLines_Errors(t *testing.T) {
+	errWrite := errors.New("write failed")
+	if err := Range(1, 10).ToJSONLines(&failingWriter{n: 4, err: errWrite}); err != errWrite {
+		t.Errorf("ToJSONLines()=%v expected %v", err, errWrite)
+	}
+
+	var b strings.Builder
+	if err := From([]any{make(chan int)}).ToJSONLines(&b); err == nil {
+		t.Errorf("ToJSONLines(chan) expected an error")
+	}
+}
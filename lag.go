@@ -0,0 +1,258 @@
+package linq
+
+// Shifted is a type used to store the results of Lag and Lead: an element of a
+// collection along with the element a given number of positions before or
+// after it.
+type Shifted struct {
+	Value   any
+	Shifted any
+}
+
+// Pairwise applies a specified function to each element of a collection and
+// the element that precedes it, and yields the results. The first element is
+// only passed to the function as previous, so Pairwise yields one result less
+// than the number of elements.
+func (q Query) Pairwise(resultSelector func(previous, current any) any) Query {
+	return q.PairwiseBy(nil, resultSelector)
+}
+
+// PairwiseT is the typed version of Pairwise.
+//
+//   - resultSelectorFn is of type "func(TSource,TSource) TResult"
+//
+// NOTE: Pairwise has better performance than PairwiseT.
+func (q Query) PairwiseT(resultSelectorFn any) Query {
+	resultSelectorGenericFunc, err := newGenericFunc(
+		"PairwiseT", "resultSelectorFn", resultSelectorFn,
+		simpleParamValidator(newElemTypeSlice(new(genericType), new(genericType)), newElemTypeSlice(new(genericType))),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	resultSelectorFunc := func(previous, current any) any {
+		return resultSelectorGenericFunc.Call(previous, current)
+	}
+
+	return q.Pairwise(resultSelectorFunc)
+}
+
+// PairwiseBy applies a specified function to each element of a collection and
+// the element that precedes it in the same partition, as returned by a
+// specified partition selector function. A partition is a run of adjacent
+// elements with equal keys, so pairs never cross a change of key.
+func (q Query) PairwiseBy(partitionSelector func(any) any,
+	resultSelector func(previous, current any) any) Query {
+	return Query{
+		Iterate: func(yield func(any) bool) {
+			var previous, partition any
+			hasPrevious := false
+
+			for item := range q.Iterate {
+				if partitionSelector != nil {
+					key := partitionSelector(item)
+					if key != partition {
+						partition, hasPrevious = key, false
+					}
+				}
+
+				if hasPrevious && !yield(resultSelector(previous, item)) {
+					return
+				}
+				previous, hasPrevious = item, true
+			}
+		},
+	}
+}
+
+// PairwiseByT is the typed version of PairwiseBy.
+//
+//   - partitionSelectorFn is of type "func(TSource) TKey"
+//   - resultSelectorFn is of type "func(TSource,TSource) TResult"
+//
+// NOTE: PairwiseBy has better performance than PairwiseByT.
+func (q Query) PairwiseByT(partitionSelectorFn any,
+	resultSelectorFn any) Query {
+	partitionSelectorGenericFunc, err := newGenericFunc(
+		"PairwiseByT", "partitionSelectorFn", partitionSelectorFn,
+		simpleParamValidator(newElemTypeSlice(new(genericType)), newElemTypeSlice(new(genericType))),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	partitionSelectorFunc := func(item any) any {
+		return partitionSelectorGenericFunc.Call(item)
+	}
+
+	resultSelectorGenericFunc, err := newGenericFunc(
+		"PairwiseByT", "resultSelectorFn", resultSelectorFn,
+		simpleParamValidator(newElemTypeSlice(new(genericType), new(genericType)), newElemTypeSlice(new(genericType))),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	resultSelectorFunc := func(previous, current any) any {
+		return resultSelectorGenericFunc.Call(previous, current)
+	}
+
+	return q.PairwiseBy(partitionSelectorFunc, resultSelectorFunc)
+}
+
+// Lag yields a Shifted for every element of a collection, with the element
+// offset positions before it as Shifted, or defaultValue for the first offset
+// elements, like the LAG window function of SQL.
+//
+// Lag keeps at most offset elements in memory. It panics if offset is
+// negative.
+func (q Query) Lag(offset int, defaultValue any) Query {
+	return q.LagBy(offset, defaultValue, nil)
+}
+
+// LagBy yields a Shifted for every element of a collection, with the element
+// offset positions before it in the same partition, as returned by a
+// specified partition selector function, or defaultValue. A partition is a run
+// of adjacent elements with equal keys, so Lag restarts at every change of
+// key.
+func (q Query) LagBy(offset int, defaultValue any,
+	partitionSelector func(any) any) Query {
+	if offset < 0 {
+		panic("Lag: offset must not be negative")
+	}
+
+	return Query{
+		Iterate: func(yield func(any) bool) {
+			// window holds the last offset elements of the partition.
+			var window []any
+			var partition any
+
+			for item := range q.Iterate {
+				if partitionSelector != nil {
+					key := partitionSelector(item)
+					if key != partition {
+						partition, window = key, nil
+					}
+				}
+
+				shifted := defaultValue
+				window = append(window, item)
+				if len(window) > offset {
+					shifted, window = window[0], window[1:]
+				}
+
+				if !yield(Shifted{Value: item, Shifted: shifted}) {
+					return
+				}
+			}
+		},
+	}
+}
+
+// LagByT is the typed version of LagBy.
+//
+//   - partitionSelectorFn is of type "func(TSource) TKey"
+//
+// NOTE: LagBy has better performance than LagByT.
+func (q Query) LagByT(offset int, defaultValue any,
+	partitionSelectorFn any) Query {
+	partitionSelectorGenericFunc, err := newGenericFunc(
+		"LagByT", "partitionSelectorFn", partitionSelectorFn,
+		simpleParamValidator(newElemTypeSlice(new(genericType)), newElemTypeSlice(new(genericType))),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	partitionSelectorFunc := func(item any) any {
+		return partitionSelectorGenericFunc.Call(item)
+	}
+
+	return q.LagBy(offset, defaultValue, partitionSelectorFunc)
+}
+
+// Lead yields a Shifted for every element of a collection, with the element
+// offset positions after it as Shifted, or defaultValue for the last offset
+// elements, like the LEAD window function of SQL.
+//
+// Lead keeps at most offset elements in memory, so the element at position i
+// is yielded once the element at position i+offset is read. It panics if
+// offset is negative.
+func (q Query) Lead(offset int, defaultValue any) Query {
+	return q.LeadBy(offset, defaultValue, nil)
+}
+
+// LeadBy yields a Shifted for every element of a collection, with the element
+// offset positions after it in the same partition, as returned by a specified
+// partition selector function, or defaultValue. A partition is a run of
+// adjacent elements with equal keys, so the last offset elements before every
+// change of key get defaultValue.
+func (q Query) LeadBy(offset int, defaultValue any,
+	partitionSelector func(any) any) Query {
+	if offset < 0 {
+		panic("Lead: offset must not be negative")
+	}
+
+	return Query{
+		Iterate: func(yield func(any) bool) {
+			// pending holds the elements of the partition that wait for the
+			// element offset positions after them.
+			var pending []any
+			var partition any
+
+			flush := func() bool {
+				for _, item := range pending {
+					if !yield(Shifted{Value: item, Shifted: defaultValue}) {
+						return false
+					}
+				}
+				pending = nil
+				return true
+			}
+
+			for item := range q.Iterate {
+				if partitionSelector != nil {
+					key := partitionSelector(item)
+					if key != partition {
+						if !flush() {
+							return
+						}
+						partition = key
+					}
+				}
+
+				pending = append(pending, item)
+				if len(pending) > offset {
+					if !yield(Shifted{Value: pending[0], Shifted: item}) {
+						return
+					}
+					pending = pending[1:]
+				}
+			}
+
+			flush()
+		},
+	}
+}
+
+// LeadByT is the typed version of LeadBy.
+//
+//   - partitionSelectorFn is of type "func(TSource) TKey"
+//
+// NOTE: LeadBy has better performance than LeadByT.
+func (q Query) LeadByT(offset int, defaultValue any,
+	partitionSelectorFn any) Query {
+	partitionSelectorGenericFunc, err := newGenericFunc(
+		"LeadByT", "partitionSelectorFn", partitionSelectorFn,
+		simpleParamValidator(newElemTypeSlice(new(genericType)), newElemTypeSlice(new(genericType))),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	partitionSelectorFunc := func(item any) any {
+		return partitionSelectorGenericFunc.Call(item)
+	}
+
+	return q.LeadBy(offset, defaultValue, partitionSelectorFunc)
+}
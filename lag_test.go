@@ -0,0 +1,110 @@
+package linq
+
+import "testing"
+
+func TestPairwise(t *testing.T) {
+	tests := []struct {
+		input  []int
+		output []any
+	}{
+		{[]int{}, nil},
+		{[]int{1}, nil},
+		{[]int{1, 4, 9, 16}, []any{3, 5, 7}},
+	}
+
+	for _, test := range tests {
+		q := From(test.input).PairwiseT(func(previous, current int) int { return current - previous })
+		if !testQueryIteration(q, test.output) {
+			t.Errorf("From(%v).PairwiseT()=%v expected %v", test.input, toSlice(q), test.output)
+		}
+	}
+}
+
+func TestPairwiseBy(t *testing.T) {
+	input := []string{"a1", "a4", "b2", "b3", "b7", "a5", "c9"}
+	want := []any{"a1a4", "b2b3", "b3b7"}
+
+	q := From(input).PairwiseByT(
+		func(s string) byte { return s[0] },
+		func(previous, current string) string { return previous + current },
+	)
+	if !testQueryIteration(q, want) {
+		t.Errorf("From(%v).PairwiseByT()=%v expected %v", input, toSlice(q), want)
+	}
+}
+
+func TestPairwiseT_PanicWhenResultSelectorFnIsInvalid(t *testing.T) {
+	mustPanicWithError(t, "PairwiseT: parameter [resultSelectorFn] has a invalid function signature. Expected: 'func(T,T)T', actual: 'func(int)int'", func() {
+		From([]int{1}).PairwiseT(func(i int) int { return i })
+	})
+}
+
+func TestLag(t *testing.T) {
+	input := []int{1, 2, 3, 4}
+
+	tests := []struct {
+		offset int
+		output []any
+	}{
+		{0, []any{Shifted{1, 1}, Shifted{2, 2}, Shifted{3, 3}, Shifted{4, 4}}},
+		{1, []any{Shifted{1, -1}, Shifted{2, 1}, Shifted{3, 2}, Shifted{4, 3}}},
+		{2, []any{Shifted{1, -1}, Shifted{2, -1}, Shifted{3, 1}, Shifted{4, 2}}},
+		{5, []any{Shifted{1, -1}, Shifted{2, -1}, Shifted{3, -1}, Shifted{4, -1}}},
+	}
+
+	for _, test := range tests {
+		if q := From(input).Lag(test.offset, -1); !testQueryIteration(q, test.output) {
+			t.Errorf("From(%v).Lag(%d)=%v expected %v", input, test.offset, toSlice(q), test.output)
+		}
+	}
+}
+
+func TestLagBy(t *testing.T) {
+	input := []int{10, 11, 12, 20, 21, 13}
+	want := []any{Shifted{10, nil}, Shifted{11, 10}, Shifted{12, 11}, Shifted{20, nil}, Shifted{21, 20}, Shifted{13, nil}}
+
+	if q := From(input).LagByT(1, nil, func(i int) int { return i / 10 }); !testQueryIteration(q, want) {
+		t.Errorf("From(%v).LagByT(1)=%v expected %v", input, toSlice(q), want)
+	}
+}
+
+func TestLag_PanicWhenOffsetIsNegative(t *testing.T) {
+	mustPanicWithError(t, "Lag: offset must not be negative", func() {
+		From([]int{1}).Lag(-1, 0)
+	})
+}
+
+func TestLead(t *testing.T) {
+	input := []int{1, 2, 3, 4}
+
+	tests := []struct {
+		offset int
+		output []any
+	}{
+		{0, []any{Shifted{1, 1}, Shifted{2, 2}, Shifted{3, 3}, Shifted{4, 4}}},
+		{1, []any{Shifted{1, 2}, Shifted{2, 3}, Shifted{3, 4}, Shifted{4, -1}}},
+		{3, []any{Shifted{1, 4}, Shifted{2, -1}, Shifted{3, -1}, Shifted{4, -1}}},
+		{5, []any{Shifted{1, -1}, Shifted{2, -1}, Shifted{3, -1}, Shifted{4, -1}}},
+	}
+
+	for _, test := range tests {
+		if q := From(input).Lead(test.offset, -1); !testQueryIteration(q, test.output) {
+			t.Errorf("From(%v).Lead(%d)=%v expected %v", input, test.offset, toSlice(q), test.output)
+		}
+	}
+}
+
+func TestLeadBy(t *testing.T) {
+	input := []int{10, 11, 12, 20, 21, 13}
+	want := []any{Shifted{10, 12}, Shifted{11, nil}, Shifted{12, nil}, Shifted{20, nil}, Shifted{21, nil}, Shifted{13, nil}}
+
+	if q := From(input).LeadByT(2, nil, func(i int) int { return i / 10 }); !testQueryIteration(q, want) {
+		t.Errorf("From(%v).LeadByT(2)=%v expected %v", input, toSlice(q), want)
+	}
+}
+
+func TestLeadByT_PanicWhenPartitionSelectorFnIsInvalid(t *testing.T) {
+	mustPanicWithError(t, "LeadByT: parameter [partitionSelectorFn] has a invalid function signature. Expected: 'func(T)T', actual: 'func(int,int)int'", func() {
+		From([]int{1}).LeadByT(1, nil, func(i, j int) int { return i })
+	})
+}
@@ -0,0 +1,126 @@
+package linq
+
+import (
+	"cmp"
+	"reflect"
+)
+
+// Number is a constraint that permits any integer, unsigned integer or float
+// type, including named types such as "type Celsius float64".
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Sum computes the sum of a collection of numeric values of type T. Unlike
+// SumInts, SumUInts and SumFloats, the result has the same type as the
+// elements, and named numeric types are supported.
+//
+// Sum panics if an element is not of type T. It returns zero if the collection
+// contains no elements.
+func Sum[T Number](q Query) (r T) {
+	for item := range q.Iterate {
+		r += item.(T)
+	}
+
+	return
+}
+
+// Product computes the product of a collection of numeric values of type T.
+//
+// Product panics if an element is not of type T. It returns one if the
+// collection contains no elements.
+func Product[T Number](q Query) T {
+	r := T(1)
+	for item := range q.Iterate {
+		r *= item.(T)
+	}
+
+	return r
+}
+
+// Average computes the average of a collection of numeric values of type T.
+// The average of integer values is computed exactly from a 128-bit sum, then
+// truncated toward zero, so it never overflows. The average of float values is
+// computed in float64 with compensated summation, then converted to T.
+//
+// Average panics if an element is not of type T. It returns false if the
+// collection contains no elements.
+func Average[T Number](q Query) (T, bool) {
+	var n uint64
+
+	switch reflect.TypeFor[T]().Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var sum int128
+		for item := range q.Iterate {
+			sum.add(int64(item.(T)))
+			n++
+		}
+
+		if n == 0 {
+			return 0, false
+		}
+		return T(sum.quo(n)), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		var sum uint128
+		for item := range q.Iterate {
+			sum.add(uint64(item.(T)))
+			n++
+		}
+
+		if n == 0 {
+			return 0, false
+		}
+		return T(sum.quo(n)), true
+	}
+
+	var sum neumaierSum
+	for item := range q.Iterate {
+		sum.add(float64(item.(T)))
+		n++
+	}
+
+	if n == 0 {
+		return 0, false
+	}
+	return T(sum.result() / float64(n)), true
+}
+
+// Min returns the minimum value in a collection of ordered values of type T.
+// Unlike Query.Min, it doesn't need a comparer, so named types such as
+// "type Celsius float64" are supported. If the collection contains NaN values,
+// the result is NaN.
+//
+// Min panics if an element is not of type T. It returns false if the
+// collection contains no elements.
+func Min[T cmp.Ordered](q Query) (r T, ok bool) {
+	for item := range q.Iterate {
+		if !ok {
+			r, ok = item.(T), true
+			continue
+		}
+		r = min(r, item.(T))
+	}
+
+	return
+}
+
+// Max returns the maximum value in a collection of ordered values of type T.
+// Unlike Query.Max, it doesn't need a comparer, so named types such as
+// "type Celsius float64" are supported. If the collection contains NaN values,
+// the result is NaN.
+//
+// Max panics if an element is not of type T. It returns false if the
+// collection contains no elements.
+func Max[T cmp.Ordered](q Query) (r T, ok bool) {
+	for item := range q.Iterate {
+		if !ok {
+			r, ok = item.(T), true
+			continue
+		}
+		r = max(r, item.(T))
+	}
+
+	return
+}
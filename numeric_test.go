@@ -0,0 +1,105 @@
+package linq
+
+import (
+	"math"
+	"testing"
+)
+
+type celsius float64
+
+type quantity uint8
+
+func TestSumGeneric(t *testing.T) {
+	if r := Sum[int](From([]int{1, 2, 3})); r != 6 {
+		t.Errorf("Sum[int]()=%v expected 6", r)
+	}
+	if r := Sum[celsius](From([]celsius{1.5, 2.5})); r != 4 {
+		t.Errorf("Sum[celsius]()=%v expected 4", r)
+	}
+	if r := Sum[quantity](From([]quantity{})); r != 0 {
+		t.Errorf("Sum[quantity]()=%v expected 0", r)
+	}
+}
+
+func TestSumGeneric_PanicWhenTypeMismatches(t *testing.T) {
+	mustPanicWithError(t, "interface conversion: interface {} is float64, not linq.celsius", func() {
+		Sum[celsius](From([]float64{1}))
+	})
+}
+
+func TestProductGeneric(t *testing.T) {
+	if r := Product[int64](From([]int64{2, 3, 4})); r != 24 {
+		t.Errorf("Product[int64]()=%v expected 24", r)
+	}
+	if r := Product[celsius](From([]celsius{})); r != 1 {
+		t.Errorf("Product[celsius]()=%v expected 1", r)
+	}
+}
+
+func TestAverageGeneric(t *testing.T) {
+	if r, ok := Average[celsius](From([]celsius{20, 21, 25})); r != 22 || !ok {
+		t.Errorf("Average[celsius]()=%v,%v expected 22,true", r, ok)
+	}
+	if r, ok := Average[quantity](From([]quantity{200, 200, 101})); r != 167 || !ok {
+		t.Errorf("Average[quantity]()=%v,%v expected 167,true", r, ok)
+	}
+	if r, ok := Average[int](From([]int{})); r != 0 || ok {
+		t.Errorf("Average[int]()=%v,%v expected 0,false", r, ok)
+	}
+
+	inf := math.Inf(1)
+	if r, ok := Average[float64](From([]float64{1, inf})); r != inf || !ok {
+		t.Errorf("Average[float64]()=%v,%v expected +Inf,true", r, ok)
+	}
+	if r, ok := Average[celsius](From([]celsius{celsius(-inf), 1})); r != celsius(-inf) || !ok {
+		t.Errorf("Average[celsius]()=%v,%v expected -Inf,true", r, ok)
+	}
+	if r, ok := Average[float64](From([]float64{inf, -inf})); !math.IsNaN(r) || !ok {
+		t.Errorf("Average[float64]()=%v,%v expected NaN,true", r, ok)
+	}
+
+	int64Tests := []struct {
+		input []int64
+		want  int64
+	}{
+		{[]int64{math.MaxInt64}, math.MaxInt64},
+		{[]int64{math.MaxInt64, math.MaxInt64, math.MaxInt64 - 3}, math.MaxInt64 - 1},
+		{[]int64{math.MinInt64, math.MinInt64}, math.MinInt64},
+		{[]int64{math.MinInt64, math.MaxInt64}, 0},
+		{[]int64{1<<53 + 1, 1<<53 + 3}, 1<<53 + 2},
+		{[]int64{-3, -4}, -3},
+	}
+	for _, test := range int64Tests {
+		if r, ok := Average[int64](From(test.input)); r != test.want || !ok {
+			t.Errorf("Average[int64](%v)=%v,%v expected %v,true", test.input, r, ok, test.want)
+		}
+	}
+
+	uint64Input := []uint64{math.MaxUint64, math.MaxUint64, math.MaxUint64}
+	if r, ok := Average[uint64](From(uint64Input)); r != math.MaxUint64 || !ok {
+		t.Errorf("Average[uint64](%v)=%v,%v expected %v,true", uint64Input, r, ok, uint64(math.MaxUint64))
+	}
+	if r, ok := Average[uint8](From([]uint8{255, 254})); r != 254 || !ok {
+		t.Errorf("Average[uint8]()=%v,%v expected 254,true", r, ok)
+	}
+}
+
+func TestMinMaxGeneric(t *testing.T) {
+	input := []celsius{21.5, -3, 40, 7}
+
+	if r, ok := Min[celsius](From(input)); r != -3 || !ok {
+		t.Errorf("Min[celsius](%v)=%v,%v expected -3,true", input, r, ok)
+	}
+	if r, ok := Max[celsius](From(input)); r != 40 || !ok {
+		t.Errorf("Max[celsius](%v)=%v,%v expected 40,true", input, r, ok)
+	}
+	if r, ok := Max[string](From([]string{"b", "c", "a"})); r != "c" || !ok {
+		t.Errorf("Max[string]()=%v,%v expected c,true", r, ok)
+	}
+	if r, ok := Min[int](From([]int{})); r != 0 || ok {
+		t.Errorf("Min[int]()=%v,%v expected 0,false", r, ok)
+	}
+	if r, _ := Max[float64](From([]float64{1, math.NaN(), 2})); !math.IsNaN(r) {
+		t.Errorf("Max[float64]() with NaN=%v expected NaN", r)
+	}
+}
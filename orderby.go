@@ -14,10 +14,12 @@ type OrderedQuery struct {
 	Query
 	original Query
 	orders   []order
+	spill    *Spill
 }
 
 // OrderBy sorts the elements of a collection in ascending order. Elements are
-// sorted according to a key.
+// sorted according to a key. The sort is stable: elements with equal keys keep
+// their relative order from the source.
 func (q Query) OrderBy(selector func(any) any) OrderedQuery {
 	return OrderedQuery{
 		orders:   []order{{selector: selector}},
@@ -102,6 +104,10 @@ func (q Query) OrderByDescendingT(selectorFn any) OrderedQuery {
 // applying any number of ThenBy or ThenByDescending methods.
 func (oq OrderedQuery) ThenBy(
 	selector func(any) any) OrderedQuery {
+	if oq.spill != nil {
+		return oq.spill.orderBy(oq.original, oq.thenBy(order{selector: selector}))
+	}
+
 	return OrderedQuery{
 		orders:   append(oq.orders, order{selector: selector}),
 		original: oq.original,
@@ -142,6 +148,10 @@ func (oq OrderedQuery) ThenByT(selectorFn any) OrderedQuery {
 // collection in descending order. This method enables you to specify multiple
 // sort criteria by applying any number of ThenBy or ThenByDescending methods.
 func (oq OrderedQuery) ThenByDescending(selector func(any) any) OrderedQuery {
+	if oq.spill != nil {
+		return oq.spill.orderBy(oq.original, oq.thenBy(order{selector: selector, desc: true}))
+	}
+
 	return OrderedQuery{
 		orders:   append(oq.orders, order{selector: selector, desc: true}),
 		original: oq.original,
@@ -180,6 +190,13 @@ func (oq OrderedQuery) ThenByDescendingT(selectorFn any) OrderedQuery {
 	return oq.ThenByDescending(selectorFunc)
 }
 
+// thenBy returns a copy of the orders of the query followed by o.
+func (oq OrderedQuery) thenBy(o order) []order {
+	orders := make([]order, len(oq.orders), len(oq.orders)+1)
+	copy(orders, oq.orders)
+	return append(orders, o)
+}
+
 // Sort returns a new query by sorting elements with provided less function in
 // ascending order. The comparer function should return true if the parameter i
 // is less than j. While this method is uglier than chaining OrderBy,
@@ -235,39 +252,35 @@ func (s sorter) Less(i, j int) bool {
 	return s.less(s.items[i], s.items[j])
 }
 
-func (q Query) sort(orders []order) (r []any) {
-	for item := range q.Iterate {
-		r = append(r, item)
-	}
-
-	if len(r) == 0 {
-		return
-	}
+// sort returns the elements of the collection sorted by orders. The keys of
+// each element are computed once, and the ties are broken by the position of
+// the elements in the source, like in the partial sort of OrderedQuery.Take
+// and in the sorted runs of Spill, so the sort is stable.
+func (q Query) sort(orders []order) []any {
+	h := &rankedHeap{orders: make([]order, len(orders))}
+	copy(h.orders, orders)
 
-	for i, j := range orders {
-		orders[i].compare = getComparer(j.selector(r[0]))
-	}
+	for item := range q.Iterate {
+		ranked := rankedItem{item: item, keys: make([]any, len(orders)), index: len(h.items)}
+		for i, o := range orders {
+			ranked.keys[i] = o.selector(item)
+		}
 
-	s := sorter{
-		items: r,
-		less: func(i, j any) bool {
-			for _, order := range orders {
-				x, y := order.selector(i), order.selector(j)
-				switch order.compare(x, y) {
-				case 0:
-					continue
-				case -1:
-					return !order.desc
-				default:
-					return order.desc
-				}
+		if ranked.index == 0 {
+			for i := range h.orders {
+				h.orders[i].compare = getComparer(ranked.keys[i])
 			}
+		}
+		h.items = append(h.items, ranked)
+	}
 
-			return false
-		}}
+	sort.Sort(h.sorter())
 
-	sort.Sort(s)
-	return
+	r := make([]any, len(h.items))
+	for i, ranked := range h.items {
+		r[i] = ranked.item
+	}
+	return r
 }
 
 func (q Query) lessSort(less func(i, j any) bool) (r []any) {
@@ -43,6 +43,24 @@ func TestOrderBy(t *testing.T) {
 	}
 }
 
+func TestOrderBy_CallsSelectorOncePerElement(t *testing.T) {
+	calls := 0
+	q := Range(0, 100).OrderBy(func(i any) any {
+		calls++
+		return i.(int) % 7
+	}).ThenByDescending(func(i any) any {
+		calls++
+		return i
+	})
+
+	if r := q.Results(); len(r) != 100 || r[0] != 98 || r[99] != 6 {
+		t.Errorf("Range(0, 100).OrderBy().ThenByDescending()=%v expected [98 ... 6]", r)
+	}
+	if calls != 200 {
+		t.Errorf("OrderBy().ThenByDescending() called the selectors %d times expected 200", calls)
+	}
+}
+
 func TestOrderByT_PanicWhenSelectorFnIsInvalid(t *testing.T) {
 	mustPanicWithError(t, "OrderByT: parameter [selectorFn] has a invalid function signature. Expected: 'func(T)T', actual: 'func(int,int)int'", func() {
 		From([]int{1, 1, 1, 2, 1, 2, 3, 4, 2}).OrderByT(func(item, j int) int { return item + 2 })
@@ -0,0 +1,254 @@
+package linq
+
+import "iter"
+
+// Partition splits a collection into the elements that satisfy a condition and
+// the elements that don't, iterating over the source only once. The relative
+// order of the elements is preserved in both results.
+func (q Query) Partition(predicate func(any) bool) (matched, unmatched []any) {
+	for item := range q.Iterate {
+		if predicate(item) {
+			matched = append(matched, item)
+		} else {
+			unmatched = append(unmatched, item)
+		}
+	}
+
+	return
+}
+
+// PartitionT is the typed version of Partition.
+//
+//   - predicateFn is of type "func(TSource) bool"
+//
+// NOTE: Partition has better performance than PartitionT.
+func (q Query) PartitionT(predicateFn any) (matched, unmatched []any) {
+	predicateGenericFunc, err := newGenericFunc(
+		"PartitionT", "predicateFn", predicateFn,
+		simpleParamValidator(newElemTypeSlice(new(genericType)), newElemTypeSlice(new(bool))),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	predicateFunc := func(item any) bool {
+		return predicateGenericFunc.Call(item).(bool)
+	}
+
+	return q.Partition(predicateFunc)
+}
+
+// PartitionLazy is the lazy version of Partition. It returns two queries that
+// share a single iteration over the source: the first one yields the elements
+// that satisfy the condition and the second one yields the elements that
+// don't.
+//
+// Elements are read from the source only when one of the queries needs them.
+// Elements read on behalf of one query that belong to the other one are
+// buffered until the other query reads them, so consuming the queries one
+// after the other buffers the whole second result, while consuming them side
+// by side buffers only the elements in between.
+//
+// The returned queries consume the source, so each element is yielded only
+// once even if the queries are iterated several times. The iteration over the
+// source is suspended between the iterations of the queries, and ends once the
+// source is exhausted. Call stop to end it earlier when the queries are not
+// iterated to the end, e.g. with First or Take, or when one of them is not
+// iterated at all: this releases the source, and the queries then yield only
+// the elements already buffered. stop can be called several times.
+func (q Query) PartitionLazy(predicate func(any) bool) (matched, unmatched Query, stop func()) {
+	p := newPartition(q, func(item any) int {
+		if predicate(item) {
+			return 0
+		}
+		return 1
+	}, nil)
+
+	return p.side(0), p.side(1), p.release
+}
+
+// SplitAt splits a collection into the first count elements and the remaining
+// ones, iterating over the source only once.
+func (q Query) SplitAt(count int) (head, tail []any) {
+	for item := range q.Iterate {
+		if len(head) < count {
+			head = append(head, item)
+		} else {
+			tail = append(tail, item)
+		}
+	}
+
+	return
+}
+
+// SplitAtLazy is the lazy version of SplitAt. It returns two queries that share
+// a single iteration over the source: the first one yields the first count
+// elements and the second one yields the remaining elements.
+//
+// Iterating over the first query never reads past the count-th element, and
+// the head is buffered only if the second query is iterated first. The
+// returned queries consume the source, so each element is yielded only once
+// even if the queries are iterated several times. As with PartitionLazy, stop
+// ends the iteration over the source when the queries are not both iterated
+// to the end.
+func (q Query) SplitAtLazy(count int) (head, tail Query, stop func()) {
+	n := 0
+	p := newPartition(q, func(item any) int {
+		if n < count {
+			n++
+			return 0
+		}
+		return 1
+	}, func(side int) bool {
+		return side == 0 && n >= count
+	})
+
+	return p.side(0), p.side(1), p.release
+}
+
+// Span splits a collection into the longest prefix of elements that satisfy a
+// condition and the remaining elements, iterating over the source only once.
+// There are no more invocations of predicate after the first element that
+// doesn't satisfy the condition.
+//
+// Span is equivalent to calling TakeWhile and SkipWhile with the same
+// predicate, but doesn't iterate over the source twice.
+func (q Query) Span(predicate func(any) bool) (prefix, rest []any) {
+	spanning := true
+	for item := range q.Iterate {
+		if spanning && predicate(item) {
+			prefix = append(prefix, item)
+		} else {
+			spanning = false
+			rest = append(rest, item)
+		}
+	}
+
+	return
+}
+
+// SpanT is the typed version of Span.
+//
+//   - predicateFn is of type "func(TSource) bool"
+//
+// NOTE: Span has better performance than SpanT.
+func (q Query) SpanT(predicateFn any) (prefix, rest []any) {
+	predicateGenericFunc, err := newGenericFunc(
+		"SpanT", "predicateFn", predicateFn,
+		simpleParamValidator(newElemTypeSlice(new(genericType)), newElemTypeSlice(new(bool))),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	predicateFunc := func(item any) bool {
+		return predicateGenericFunc.Call(item).(bool)
+	}
+
+	return q.Span(predicateFunc)
+}
+
+// SpanLazy is the lazy version of Span. It returns two queries that share a
+// single iteration over the source: the first one yields the longest prefix of
+// elements that satisfy the condition and the second one yields the remaining
+// elements.
+//
+// Iterating over the first query reads at most one element past the prefix,
+// and the prefix is buffered only if the second query is iterated first. The
+// returned queries consume the source, so each element is yielded only once
+// even if the queries are iterated several times. As with PartitionLazy, stop
+// ends the iteration over the source when the queries are not both iterated
+// to the end.
+func (q Query) SpanLazy(predicate func(any) bool) (prefix, rest Query, stop func()) {
+	spanning := true
+	p := newPartition(q, func(item any) int {
+		if spanning && predicate(item) {
+			return 0
+		}
+		spanning = false
+		return 1
+	}, func(side int) bool {
+		return side == 0 && !spanning
+	})
+
+	return p.side(0), p.side(1), p.release
+}
+
+// partition distributes the elements of a single iteration over a source
+// between two queries, buffering the elements that have been read but not yet
+// consumed by their query.
+//
+// Like queries, a partition is not safe for concurrent use. It holds no lock
+// while reading the source, so a predicate can iterate over the other query.
+type partition struct {
+	source  Query
+	next    func() (any, bool)
+	stop    func()
+	done    bool
+	buffers [2][]any
+
+	// classify returns the index of the query an element belongs to. It is
+	// called once per element, in source order.
+	classify func(any) int
+	// complete, if not nil, reports whether a query cannot receive any more
+	// elements, so that its iteration ends without reading the source.
+	complete func(side int) bool
+}
+
+func newPartition(source Query, classify func(any) int,
+	complete func(side int) bool) *partition {
+	return &partition{source: source, classify: classify, complete: complete}
+}
+
+// side returns the query that yields the elements of the given side.
+func (p *partition) side(side int) Query {
+	return Query{
+		Iterate: func(yield func(any) bool) {
+			for {
+				item, ok := p.take(side)
+				if !ok || !yield(item) {
+					return
+				}
+			}
+		},
+	}
+}
+
+// release ends the iteration over the source, if it was started. The
+// elements already buffered can still be taken.
+func (p *partition) release() {
+	p.done = true
+	if p.stop != nil {
+		p.stop()
+	}
+}
+
+// take returns the next element of the given side, reading the source until
+// one is found.
+func (p *partition) take(side int) (any, bool) {
+	for {
+		if buffer := p.buffers[side]; len(buffer) > 0 {
+			item := buffer[0]
+			buffer[0] = nil
+			p.buffers[side] = buffer[1:]
+			return item, true
+		}
+
+		if p.done || (p.complete != nil && p.complete(side)) {
+			return nil, false
+		}
+
+		if p.next == nil {
+			p.next, p.stop = iter.Pull(p.source.Iterate)
+		}
+
+		item, ok := p.next()
+		if !ok {
+			p.release()
+			return nil, false
+		}
+
+		target := p.classify(item)
+		p.buffers[target] = append(p.buffers[target], item)
+	}
+}
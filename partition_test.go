@@ -0,0 +1,239 @@
+package linq
+
+import (
+	"reflect"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestPartition(t *testing.T) {
+	input := []int{1, 2, 3, 4, 5, 6, 7}
+	wantMatched := []any{2, 4, 6}
+	wantUnmatched := []any{1, 3, 5, 7}
+
+	matched, unmatched := From(input).Partition(func(i any) bool {
+		return i.(int)%2 == 0
+	})
+
+	if !reflect.DeepEqual(matched, wantMatched) || !reflect.DeepEqual(unmatched, wantUnmatched) {
+		t.Errorf("From(%v).Partition()=%v,%v expected %v,%v", input, matched, unmatched, wantMatched, wantUnmatched)
+	}
+}
+
+func TestPartition_Channel(t *testing.T) {
+	c := make(chan int, 4)
+	c <- 1
+	c <- 2
+	c <- 3
+	c <- 4
+	close(c)
+
+	matched, unmatched := FromChannel(c).Partition(func(i any) bool {
+		return i.(int) > 2
+	})
+
+	if !reflect.DeepEqual(matched, []any{3, 4}) || !reflect.DeepEqual(unmatched, []any{1, 2}) {
+		t.Errorf("FromChannel().Partition()=%v,%v expected [3 4],[1 2]", matched, unmatched)
+	}
+}
+
+func TestPartitionT_PanicWhenPredicateFnIsInvalid(t *testing.T) {
+	mustPanicWithError(t, "PartitionT: parameter [predicateFn] has a invalid function signature. Expected: 'func(T)bool', actual: 'func(int)int'", func() {
+		From([]int{1, 2, 3}).PartitionT(func(item int) int { return item + 2 })
+	})
+}
+
+func TestPartitionLazy(t *testing.T) {
+	c := make(chan int, 7)
+	for i := 1; i <= 7; i++ {
+		c <- i
+	}
+	close(c)
+
+	read := 0
+	source := FromChannel(c).Select(func(i any) any {
+		read++
+		return i
+	})
+
+	even, odd, stop := source.PartitionLazy(func(i any) bool {
+		return i.(int)%2 == 0
+	})
+	defer stop()
+
+	if got := even.First(); got != 2 {
+		t.Errorf("PartitionLazy() first matched=%v expected 2", got)
+	}
+	if read != 2 {
+		t.Errorf("PartitionLazy() read %d elements expected 2", read)
+	}
+
+	if !assertQueryOutput(odd, []any{1, 3, 5, 7}) {
+		t.Errorf("PartitionLazy() unmatched=%v expected [1 3 5 7]", toSlice(odd))
+	}
+	if !assertQueryOutput(even, []any{4, 6}) {
+		t.Errorf("PartitionLazy() matched=%v expected [4 6]", toSlice(even))
+	}
+	if !assertQueryOutput(even, nil) {
+		t.Errorf("PartitionLazy() reiteration=%v expected []", toSlice(even))
+	}
+}
+
+func TestSplitAt(t *testing.T) {
+	tests := []struct {
+		input any
+		count int
+		head  []any
+		tail  []any
+	}{
+		{[]int{1, 2, 3, 4, 5}, 2, []any{1, 2}, []any{3, 4, 5}},
+		{[]int{1, 2}, 5, []any{1, 2}, nil},
+		{[]int{1, 2}, 0, nil, []any{1, 2}},
+		{"abc", 1, []any{'a'}, []any{'b', 'c'}},
+	}
+
+	for _, test := range tests {
+		head, tail := From(test.input).SplitAt(test.count)
+		if !reflect.DeepEqual(head, test.head) || !reflect.DeepEqual(tail, test.tail) {
+			t.Errorf("From(%v).SplitAt(%d)=%v,%v expected %v,%v", test.input, test.count, head, tail, test.head, test.tail)
+		}
+	}
+}
+
+func TestSplitAtLazy(t *testing.T) {
+	read := 0
+	source := Range(1, 5).Select(func(i any) any {
+		read++
+		return i
+	})
+
+	head, tail, stop := source.SplitAtLazy(2)
+	defer stop()
+
+	if !assertQueryOutput(head, []any{1, 2}) {
+		t.Errorf("SplitAtLazy(2) head=%v expected [1 2]", toSlice(head))
+	}
+	if read != 2 {
+		t.Errorf("SplitAtLazy(2) read %d elements expected 2", read)
+	}
+	if !assertQueryOutput(tail, []any{3, 4, 5}) {
+		t.Errorf("SplitAtLazy(2) tail=%v expected [3 4 5]", toSlice(tail))
+	}
+}
+
+func TestSplitAtLazy_TailFirst(t *testing.T) {
+	head, tail, stop := Range(1, 5).SplitAtLazy(2)
+	defer stop()
+
+	if !assertQueryOutput(tail, []any{3, 4, 5}) {
+		t.Errorf("SplitAtLazy(2) tail=%v expected [3 4 5]", toSlice(tail))
+	}
+	if !assertQueryOutput(head, []any{1, 2}) {
+		t.Errorf("SplitAtLazy(2) head=%v expected [1 2]", toSlice(head))
+	}
+}
+
+func TestSpan(t *testing.T) {
+	input := []int{1, 2, 3, 10, 4, 5}
+	calls := 0
+
+	prefix, rest := From(input).Span(func(i any) bool {
+		calls++
+		return i.(int) < 5
+	})
+
+	if !reflect.DeepEqual(prefix, []any{1, 2, 3}) || !reflect.DeepEqual(rest, []any{10, 4, 5}) {
+		t.Errorf("From(%v).Span()=%v,%v expected [1 2 3],[10 4 5]", input, prefix, rest)
+	}
+	if calls != 4 {
+		t.Errorf("From(%v).Span() called predicate %d times expected 4", input, calls)
+	}
+}
+
+func TestSpanT_PanicWhenPredicateFnIsInvalid(t *testing.T) {
+	mustPanicWithError(t, "SpanT: parameter [predicateFn] has a invalid function signature. Expected: 'func(T)bool', actual: 'func(int)int'", func() {
+		From([]int{1, 2, 3}).SpanT(func(item int) int { return item + 2 })
+	})
+}
+
+func TestSpanLazy(t *testing.T) {
+	prefix, rest, stop := From([]int{1, 2, 3, 10, 4, 5}).SpanLazy(func(i any) bool {
+		return i.(int) < 5
+	})
+	defer stop()
+
+	if !assertQueryOutput(rest, []any{10, 4, 5}) {
+		t.Errorf("SpanLazy() rest=%v expected [10 4 5]", toSlice(rest))
+	}
+	if !assertQueryOutput(prefix, []any{1, 2, 3}) {
+		t.Errorf("SpanLazy() prefix=%v expected [1 2 3]", toSlice(prefix))
+	}
+}
+
+// waitGoroutines waits for the number of goroutines to drop to n, and returns
+// the last count.
+func waitGoroutines(n int) int {
+	count := runtime.NumGoroutine()
+	for i := 0; i < 100 && count > n; i++ {
+		time.Sleep(time.Millisecond)
+		count = runtime.NumGoroutine()
+	}
+	return count
+}
+
+func TestPartitionLazy_Stop(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	for range 100 {
+		head, _, stop := Range(1, 10).SplitAtLazy(2)
+		head.Results()
+		stop()
+
+		even, _, stop := Range(1, 10).PartitionLazy(func(i any) bool { return i.(int)%2 == 0 })
+		even.First()
+		stop()
+		stop()
+
+		prefix, rest, _ := Range(1, 10).SpanLazy(func(i any) bool { return i.(int) < 3 })
+		prefix.Results()
+		rest.Results()
+	}
+
+	if after := waitGoroutines(before); after > before {
+		t.Errorf("PartitionLazy() left %d goroutines expected %d", after, before)
+	}
+}
+
+func TestPartitionLazy_StopReleasesSource(t *testing.T) {
+	released := false
+	source := Query{
+		Iterate: func(yield func(any) bool) {
+			defer func() { released = true }()
+			for i := 1; i <= 10; i++ {
+				if !yield(i) {
+					return
+				}
+			}
+		},
+	}
+
+	even, odd, stop := source.PartitionLazy(func(i any) bool { return i.(int)%2 == 0 })
+	if got := even.First(); got != 2 {
+		t.Errorf("PartitionLazy() first matched=%v expected 2", got)
+	}
+	if released {
+		t.Errorf("PartitionLazy() released the source before stop")
+	}
+
+	stop()
+	if !released {
+		t.Errorf("PartitionLazy() didn't release the source on stop")
+	}
+	if !assertQueryOutput(odd, []any{1}) {
+		t.Errorf("PartitionLazy() unmatched after stop=%v expected [1]", toSlice(odd))
+	}
+	if !assertQueryOutput(even, nil) {
+		t.Errorf("PartitionLazy() matched after stop=%v expected []", toSlice(even))
+	}
+}
@@ -0,0 +1,273 @@
+package linq
+
+// Ranked is a type used to store the results of RowNumber, Rank, DenseRank and
+// NTile: an element of a sorted collection along with its 1-based rank.
+type Ranked struct {
+	Value any
+	Rank  int
+}
+
+// RowNumber numbers the elements of a sorted collection, like the ROW_NUMBER
+// window function of SQL. It yields a Ranked for every element, in order, with
+// ranks 1, 2, 3 and so on, whether the keys of the elements are equal or not.
+func (oq OrderedQuery) RowNumber() Query {
+	return oq.RowNumberBy(nil)
+}
+
+// RowNumberBy numbers the elements of a sorted collection in each of the
+// partitions returned by a specified partition selector function, like the
+// ROW_NUMBER() OVER (PARTITION BY ...) window function of SQL. The elements
+// are yielded in order, and the numbering restarts at 1 in every partition.
+func (oq OrderedQuery) RowNumberBy(partitionSelector func(any) any) Query {
+	return oq.rank(partitionSelector, func(s *rankState) int {
+		return s.rows
+	})
+}
+
+// RowNumberByT is the typed version of RowNumberBy.
+//
+//   - partitionSelectorFn is of type "func(TSource) TKey"
+//
+// NOTE: RowNumberBy has better performance than RowNumberByT.
+func (oq OrderedQuery) RowNumberByT(partitionSelectorFn any) Query {
+	partitionSelectorGenericFunc, err := newGenericFunc(
+		"RowNumberByT", "partitionSelectorFn", partitionSelectorFn,
+		simpleParamValidator(newElemTypeSlice(new(genericType)), newElemTypeSlice(new(genericType))),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	partitionSelectorFunc := func(item any) any {
+		return partitionSelectorGenericFunc.Call(item)
+	}
+
+	return oq.RowNumberBy(partitionSelectorFunc)
+}
+
+// Rank ranks the elements of a sorted collection, like the RANK window
+// function of SQL. Elements whose keys, as returned by the selectors of the
+// OrderBy, OrderByDescending, ThenBy and ThenByDescending chain, are equal get
+// the same rank, and the ranks after them are skipped, e.g. 1, 2, 2, 4.
+func (oq OrderedQuery) Rank() Query {
+	return oq.RankBy(nil)
+}
+
+// RankBy ranks the elements of a sorted collection in each of the partitions
+// returned by a specified partition selector function, like the
+// RANK() OVER (PARTITION BY ...) window function of SQL. The elements are
+// yielded in order, and the ranking restarts at 1 in every partition.
+func (oq OrderedQuery) RankBy(partitionSelector func(any) any) Query {
+	return oq.rank(partitionSelector, func(s *rankState) int {
+		return s.rank
+	})
+}
+
+// RankByT is the typed version of RankBy.
+//
+//   - partitionSelectorFn is of type "func(TSource) TKey"
+//
+// NOTE: RankBy has better performance than RankByT.
+func (oq OrderedQuery) RankByT(partitionSelectorFn any) Query {
+	partitionSelectorGenericFunc, err := newGenericFunc(
+		"RankByT", "partitionSelectorFn", partitionSelectorFn,
+		simpleParamValidator(newElemTypeSlice(new(genericType)), newElemTypeSlice(new(genericType))),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	partitionSelectorFunc := func(item any) any {
+		return partitionSelectorGenericFunc.Call(item)
+	}
+
+	return oq.RankBy(partitionSelectorFunc)
+}
+
+// DenseRank ranks the elements of a sorted collection, like the DENSE_RANK
+// window function of SQL. Elements whose keys are equal get the same rank, as
+// with Rank, but no rank is skipped after them, e.g. 1, 2, 2, 3.
+func (oq OrderedQuery) DenseRank() Query {
+	return oq.DenseRankBy(nil)
+}
+
+// DenseRankBy ranks the elements of a sorted collection in each of the
+// partitions returned by a specified partition selector function, like the
+// DENSE_RANK() OVER (PARTITION BY ...) window function of SQL. The elements
+// are yielded in order, and the ranking restarts at 1 in every partition.
+func (oq OrderedQuery) DenseRankBy(partitionSelector func(any) any) Query {
+	return oq.rank(partitionSelector, func(s *rankState) int {
+		return s.dense
+	})
+}
+
+// DenseRankByT is the typed version of DenseRankBy.
+//
+//   - partitionSelectorFn is of type "func(TSource) TKey"
+//
+// NOTE: DenseRankBy has better performance than DenseRankByT.
+func (oq OrderedQuery) DenseRankByT(partitionSelectorFn any) Query {
+	partitionSelectorGenericFunc, err := newGenericFunc(
+		"DenseRankByT", "partitionSelectorFn", partitionSelectorFn,
+		simpleParamValidator(newElemTypeSlice(new(genericType)), newElemTypeSlice(new(genericType))),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	partitionSelectorFunc := func(item any) any {
+		return partitionSelectorGenericFunc.Call(item)
+	}
+
+	return oq.DenseRankBy(partitionSelectorFunc)
+}
+
+// NTile distributes the elements of a sorted collection into count buckets of
+// the same size, like the NTILE window function of SQL. It yields a Ranked for
+// every element, in order, with the 1-based number of its bucket as Rank. When
+// the number of elements is not divisible by count, the first buckets get one
+// more element than the last ones.
+//
+// NTile panics if count is not positive. Unlike the other window functions,
+// it buffers the whole collection to count its elements.
+func (oq OrderedQuery) NTile(count int) Query {
+	return oq.NTileBy(count, nil)
+}
+
+// NTileBy distributes the elements of a sorted collection into count buckets
+// in each of the partitions returned by a specified partition selector
+// function, like the NTILE() OVER (PARTITION BY ...) window function of SQL.
+// The elements are yielded in order.
+func (oq OrderedQuery) NTileBy(count int, partitionSelector func(any) any) Query {
+	if count <= 0 {
+		panic("NTile: count must be positive")
+	}
+
+	if partitionSelector == nil {
+		partitionSelector = func(any) any { return nil }
+	}
+
+	return Query{
+		Iterate: func(yield func(any) bool) {
+			var items, partitions []any
+			sizes := make(map[any]int)
+			for item := range oq.Iterate {
+				partition := partitionSelector(item)
+				items = append(items, item)
+				partitions = append(partitions, partition)
+				sizes[partition]++
+			}
+
+			rows := make(map[any]int)
+			for i, item := range items {
+				row := rows[partitions[i]]
+				rows[partitions[i]]++
+
+				// The first size%count buckets have one more element.
+				size := sizes[partitions[i]]
+				small, large := size/count, size%count
+				var tile int
+				if row < large*(small+1) {
+					tile = row / (small + 1)
+				} else {
+					tile = large + (row-large*(small+1))/small
+				}
+
+				if !yield(Ranked{Value: item, Rank: tile + 1}) {
+					return
+				}
+			}
+		},
+	}
+}
+
+// NTileByT is the typed version of NTileBy.
+//
+//   - partitionSelectorFn is of type "func(TSource) TKey"
+//
+// NOTE: NTileBy has better performance than NTileByT.
+func (oq OrderedQuery) NTileByT(count int, partitionSelectorFn any) Query {
+	partitionSelectorGenericFunc, err := newGenericFunc(
+		"NTileByT", "partitionSelectorFn", partitionSelectorFn,
+		simpleParamValidator(newElemTypeSlice(new(genericType)), newElemTypeSlice(new(genericType))),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	partitionSelectorFunc := func(item any) any {
+		return partitionSelectorGenericFunc.Call(item)
+	}
+
+	return oq.NTileBy(count, partitionSelectorFunc)
+}
+
+// rankState is the state of the ranking of a partition.
+type rankState struct {
+	rows  int
+	rank  int
+	dense int
+	keys  []any
+}
+
+// rank yields a Ranked for every element of the sorted collection, with the
+// rank returned by f for the state of the partition of the element.
+func (oq OrderedQuery) rank(partitionSelector func(any) any,
+	f func(s *rankState) int) Query {
+	return Query{
+		Iterate: func(yield func(any) bool) {
+			orders := make([]order, len(oq.orders))
+			copy(orders, oq.orders)
+
+			states := make(map[any]*rankState)
+			first := true
+			for item := range oq.Iterate {
+				keys := make([]any, len(orders))
+				for i, o := range orders {
+					keys[i] = o.selector(item)
+				}
+
+				if first {
+					for i := range orders {
+						orders[i].compare = getComparer(keys[i])
+					}
+					first = false
+				}
+
+				var partition any
+				if partitionSelector != nil {
+					partition = partitionSelector(item)
+				}
+
+				s, ok := states[partition]
+				if !ok {
+					s = &rankState{}
+					states[partition] = s
+				}
+
+				s.rows++
+				if s.rows == 1 || !equalKeys(orders, s.keys, keys) {
+					s.rank = s.rows
+					s.dense++
+				}
+				s.keys = keys
+
+				if !yield(Ranked{Value: item, Rank: f(s)}) {
+					return
+				}
+			}
+		},
+	}
+}
+
+// equalKeys reports whether the sort keys a and b are equal according to the
+// comparers of orders.
+func equalKeys(orders []order, a, b []any) bool {
+	for i, o := range orders {
+		if o.compare(a[i], b[i]) != 0 {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,127 @@
+package linq
+
+import "testing"
+
+type score struct {
+	team   string
+	player string
+	points int
+}
+
+var scores = []score{
+	{"red", "ann", 30},
+	{"blue", "bob", 25},
+	{"red", "cat", 25},
+	{"blue", "dan", 30},
+	{"red", "eve", 25},
+	{"blue", "fay", 10},
+	{"red", "gil", 20},
+}
+
+func orderedScores() OrderedQuery {
+	return From(scores).OrderByDescending(func(i any) any { return i.(score).points })
+}
+
+func ranks(q Query) (r []any) {
+	for item := range q.Iterate {
+		ranked := item.(Ranked)
+		r = append(r, ranked.Value.(score).player, ranked.Rank)
+	}
+	return
+}
+
+func TestRowNumber(t *testing.T) {
+	want := []any{"ann", 1, "dan", 2, "bob", 3, "cat", 4, "eve", 5, "gil", 6, "fay", 7}
+	if got := ranks(orderedScores().RowNumber()); !testQueryIteration(From(got), want) {
+		t.Errorf("RowNumber()=%v expected %v", got, want)
+	}
+
+	want = []any{"ann", 1, "dan", 1, "bob", 2, "cat", 2, "eve", 3, "gil", 4, "fay", 3}
+	got := ranks(orderedScores().RowNumberByT(func(s score) string { return s.team }))
+	if !testQueryIteration(From(got), want) {
+		t.Errorf("RowNumberByT()=%v expected %v", got, want)
+	}
+}
+
+func TestRank(t *testing.T) {
+	want := []any{"ann", 1, "dan", 1, "bob", 3, "cat", 3, "eve", 3, "gil", 6, "fay", 7}
+	if got := ranks(orderedScores().Rank()); !testQueryIteration(From(got), want) {
+		t.Errorf("Rank()=%v expected %v", got, want)
+	}
+
+	want = []any{"ann", 1, "dan", 1, "bob", 2, "cat", 2, "eve", 2, "gil", 4, "fay", 3}
+	got := ranks(orderedScores().RankByT(func(s score) string { return s.team }))
+	if !testQueryIteration(From(got), want) {
+		t.Errorf("RankByT()=%v expected %v", got, want)
+	}
+}
+
+func TestRankUsesAllKeys(t *testing.T) {
+	q := orderedScores().ThenBy(func(i any) any { return i.(score).team })
+
+	want := []any{"dan", 1, "ann", 2, "bob", 3, "cat", 4, "eve", 4, "gil", 6, "fay", 7}
+	if got := ranks(q.Rank()); !testQueryIteration(From(got), want) {
+		t.Errorf("ThenBy().Rank()=%v expected %v", got, want)
+	}
+
+	want = []any{"dan", 1, "ann", 2, "bob", 3, "cat", 4, "eve", 4, "gil", 5, "fay", 6}
+	if got := ranks(q.DenseRank()); !testQueryIteration(From(got), want) {
+		t.Errorf("ThenBy().DenseRank()=%v expected %v", got, want)
+	}
+}
+
+func TestDenseRank(t *testing.T) {
+	want := []any{"ann", 1, "dan", 1, "bob", 2, "cat", 2, "eve", 2, "gil", 3, "fay", 4}
+	if got := ranks(orderedScores().DenseRank()); !testQueryIteration(From(got), want) {
+		t.Errorf("DenseRank()=%v expected %v", got, want)
+	}
+
+	want = []any{"ann", 1, "dan", 1, "bob", 2, "cat", 2, "eve", 2, "gil", 3, "fay", 3}
+	got := ranks(orderedScores().DenseRankByT(func(s score) string { return s.team }))
+	if !testQueryIteration(From(got), want) {
+		t.Errorf("DenseRankByT()=%v expected %v", got, want)
+	}
+}
+
+func TestNTile(t *testing.T) {
+	tests := []struct {
+		count int
+		want  []any
+	}{
+		{1, []any{"ann", 1, "dan", 1, "bob", 1, "cat", 1, "eve", 1, "gil", 1, "fay", 1}},
+		{3, []any{"ann", 1, "dan", 1, "bob", 1, "cat", 2, "eve", 2, "gil", 3, "fay", 3}},
+		{4, []any{"ann", 1, "dan", 1, "bob", 2, "cat", 2, "eve", 3, "gil", 3, "fay", 4}},
+		{10, []any{"ann", 1, "dan", 2, "bob", 3, "cat", 4, "eve", 5, "gil", 6, "fay", 7}},
+	}
+
+	for _, test := range tests {
+		if got := ranks(orderedScores().NTile(test.count)); !testQueryIteration(From(got), test.want) {
+			t.Errorf("NTile(%d)=%v expected %v", test.count, got, test.want)
+		}
+	}
+
+	want := []any{"ann", 1, "dan", 1, "bob", 1, "cat", 1, "eve", 2, "gil", 2, "fay", 2}
+	got := ranks(orderedScores().NTileByT(2, func(s score) string { return s.team }))
+	if !testQueryIteration(From(got), want) {
+		t.Errorf("NTileByT(2)=%v expected %v", got, want)
+	}
+}
+
+func TestNTile_PanicWhenCountIsNotPositive(t *testing.T) {
+	mustPanicWithError(t, "NTile: count must be positive", func() {
+		orderedScores().NTile(0)
+	})
+}
+
+func TestRankByT_PanicWhenPartitionSelectorFnIsInvalid(t *testing.T) {
+	mustPanicWithError(t, "RankByT: parameter [partitionSelectorFn] has a invalid function signature. Expected: 'func(T)T', actual: 'func(int,int)int'", func() {
+		From([]int{1}).OrderBy(identity).RankByT(func(i, j int) int { return i })
+	})
+}
+
+func TestRankEarlyStop(t *testing.T) {
+	want := []any{Ranked{Value: 1, Rank: 1}, Ranked{Value: 1, Rank: 1}, Ranked{Value: 2, Rank: 3}}
+	if q := From([]int{2, 1, 3, 1}).OrderBy(identity).Rank().Take(3); !testQueryIteration(q, want) {
+		t.Errorf("Rank().Take(3)=%v expected %v", toSlice(q), want)
+	}
+}
@@ -0,0 +1,60 @@
+package linq
+
+import (
+	"bufio"
+	"context"
+	"io"
+)
+
+// ReaderOptions configures FromReader.
+type ReaderOptions struct {
+	// Split is the function that splits the input into tokens, such as
+	// bufio.ScanLines, bufio.ScanWords or bufio.ScanRunes. If it is nil, the
+	// input is split into lines.
+	Split bufio.SplitFunc
+	// MaxTokenSize is the maximum size of a token, in bytes. If it is zero,
+	// bufio.MaxScanTokenSize is used. A longer token stops the iteration with
+	// bufio.ErrTooLong.
+	MaxTokenSize int
+	// Context, if it is not nil, stops the iteration when it is canceled, like
+	// in FromChannelWithContext. The context is checked before every token, so
+	// a pending read of r is not interrupted.
+	Context context.Context
+}
+
+// FromReader initializes a linq query that reads the tokens of r, by default
+// its lines without their end-of-line marker, and yields them as strings.
+//
+// The reader is read as the query is iterated, and no further than needed, so
+// a query that stops early, e.g. with Take, leaves the rest of the input
+// unread. The reader is not closed.
+//
+// The errors of the reader and of the split function, as well as the error of
+// a canceled Context, stop the iteration and are returned by the Err method of
+// the query.
+func FromReader(r io.Reader, options ReaderOptions) SourceQuery {
+	return newSourceQuery(func(yield func(any) bool) error {
+		scanner := bufio.NewScanner(r)
+		if options.Split != nil {
+			scanner.Split(options.Split)
+		}
+		if options.MaxTokenSize > 0 {
+			scanner.Buffer(make([]byte, 0, min(options.MaxTokenSize, 4096)), options.MaxTokenSize)
+		}
+
+		for {
+			if options.Context != nil {
+				if err := options.Context.Err(); err != nil {
+					return err
+				}
+			}
+
+			if !scanner.Scan() {
+				return scanner.Err()
+			}
+			if !yield(scanner.Text()) {
+				return nil
+			}
+		}
+	})
+}
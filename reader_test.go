@@ -0,0 +1,111 @@
+package linq
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestFromReader(t *testing.T) {
+	input := "first line\nsecond  line\r\n\nlast"
+
+	tests := []struct {
+		split  bufio.SplitFunc
+		output []any
+	}{
+		{nil, []any{"first line", "second  line", "", "last"}},
+		{bufio.ScanLines, []any{"first line", "second  line", "", "last"}},
+		{bufio.ScanWords, []any{"first", "line", "second", "line", "last"}},
+	}
+
+	for _, test := range tests {
+		q := FromReader(strings.NewReader(input), ReaderOptions{Split: test.split})
+		if !assertQueryOutput(q.Query, test.output) {
+			t.Errorf("FromReader(%q)=%v expected %v", input, toSlice(q.Query), test.output)
+		}
+		if err := q.Err(); err != nil {
+			t.Errorf("FromReader(%q).Err()=%v expected nil", input, err)
+		}
+	}
+}
+
+func TestFromReader_CustomSplit(t *testing.T) {
+	commas := func(data []byte, atEOF bool) (int, []byte, error) {
+		if i := bytes.IndexByte(data, ','); i >= 0 {
+			return i + 1, data[:i], nil
+		}
+		if atEOF && len(data) > 0 {
+			return len(data), data, nil
+		}
+		return 0, nil, nil
+	}
+
+	q := FromReader(strings.NewReader("a,bb,,c"), ReaderOptions{Split: commas})
+	if w := []any{"a", "bb", "", "c"}; !assertQueryOutput(q.Query, w) {
+		t.Errorf("FromReader(commas)=%v expected %v", toSlice(q.Query), w)
+	}
+}
+
+func TestFromReader_MaxTokenSize(t *testing.T) {
+	q := FromReader(strings.NewReader("short\nmuch too long\nshort"), ReaderOptions{MaxTokenSize: 8})
+	if w := []any{"short"}; !assertQueryOutput(q.Query, w) {
+		t.Errorf("FromReader(MaxTokenSize: 8)=%v expected %v", toSlice(q.Query), w)
+	}
+	if err := q.Err(); !errors.Is(err, bufio.ErrTooLong) {
+		t.Errorf("FromReader(MaxTokenSize: 8).Err()=%v expected %v", err, bufio.ErrTooLong)
+	}
+}
+
+func TestFromReader_ReadError(t *testing.T) {
+	errRead := errors.New("read failed")
+	r := io.MultiReader(strings.NewReader("one\ntwo\n"), iotest.ErrReader(errRead))
+
+	q := FromReader(r, ReaderOptions{})
+	if w := []any{"one", "two"}; !assertQueryOutput(q.Query, w) {
+		t.Errorf("FromReader()=%v expected %v", toSlice(q.Query), w)
+	}
+	if err := q.Err(); err != errRead {
+		t.Errorf("FromReader().Err()=%v expected %v", err, errRead)
+	}
+}
+
+func TestFromReader_EarlyStop(t *testing.T) {
+	// OneByteReader makes the scanner read no further than the lines it needs.
+	r := strings.NewReader("one\ntwo\nthree\nfour\n")
+	q := FromReader(iotest.OneByteReader(r), ReaderOptions{})
+
+	if w := []any{"one", "two"}; !assertQueryOutput(q.Take(2), w) {
+		t.Errorf("FromReader().Take(2)=%v expected %v", toSlice(q.Take(2)), w)
+	}
+	if err := q.Err(); err != nil {
+		t.Errorf("FromReader().Take(2) Err()=%v expected nil", err)
+	}
+	if r.Len() == 0 {
+		t.Errorf("FromReader().Take(2) read the whole input")
+	}
+}
+
+func TestFromReader_Context(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	q := FromReader(strings.NewReader("one\ntwo\nthree"), ReaderOptions{Context: ctx})
+
+	var got []any
+	for line := range q.Iterate {
+		got = append(got, line)
+		cancel()
+	}
+
+	if w := []any{"one"}; !assertQueryOutput(From(got), w) {
+		t.Errorf("FromReader(Context)=%v expected %v", got, w)
+	}
+	if err := q.Err(); err != context.Canceled {
+		t.Errorf("FromReader(Context).Err()=%v expected %v", err, context.Canceled)
+	}
+}
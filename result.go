@@ -1,12 +1,18 @@
 package linq
 
 import (
+	"errors"
 	"iter"
 	"math"
+	"math/bits"
 	"reflect"
 	"slices"
 )
 
+// ErrOverflow is returned by the checked sums when the result doesn't fit in
+// the type of the sum.
+var ErrOverflow = errors.New("linq: integer overflow")
+
 // All determines whether all elements of a collection satisfy a condition.
 func (q Query) All(predicate func(any) bool) bool {
 	for item := range q.Iterate {
@@ -84,7 +90,19 @@ func (q Query) AnyWithT(predicateFn any) bool {
 // Average computes the average of a collection of numeric values.
 // It panics if the sequence contains non-numeric types.
 // It returns math.NaN() if the sequence is empty.
-func (q Query) Average() (r float64) {
+//
+// Integer values are accumulated in 128 bits, so the intermediate sum can't
+// overflow, and float values are accumulated with Kahan-Neumaier compensated
+// summation.
+func (q Query) Average() float64 {
+	return q.AverageBy(identity)
+}
+
+// AverageBy computes the average of the numeric values obtained by invoking a
+// transform function on each element of a collection.
+// It panics if selector returns non-numeric types.
+// It returns math.NaN() if the sequence is empty.
+func (q Query) AverageBy(selector func(any) any) (r float64) {
 	next, stop := iter.Pull(q.Iterate)
 	defer stop()
 
@@ -93,41 +111,77 @@ func (q Query) Average() (r float64) {
 		return math.NaN()
 	}
 
-	n := 1
-	switch item.(type) {
+	value := selector(item)
+	n := 0
+	switch value.(type) {
 	case int, int8, int16, int32, int64:
-		conv := getIntConverter(item)
-		sum := conv(item)
+		conv := getIntConverter(value)
+		var sum int128
 
-		for item, ok = next(); ok; item, ok = next() {
-			sum += conv(item)
+		for ok {
+			sum.add(conv(value))
 			n++
+
+			if item, ok = next(); ok {
+				value = selector(item)
+			}
 		}
 
-		r = float64(sum)
+		r = sum.float64()
 	case uint, uint8, uint16, uint32, uint64:
-		conv := getUIntConverter(item)
-		sum := conv(item)
+		conv := getUIntConverter(value)
+		var sum uint128
 
-		for item, ok = next(); ok; item, ok = next() {
-			sum += conv(item)
+		for ok {
+			sum.add(conv(value))
 			n++
+
+			if item, ok = next(); ok {
+				value = selector(item)
+			}
 		}
 
-		r = float64(sum)
+		r = sum.float64()
 	default:
-		conv := getFloatConverter(item)
-		r = conv(item)
+		conv := getFloatConverter(value)
+		var sum neumaierSum
 
-		for item, ok = next(); ok; item, ok = next() {
-			r += conv(item)
+		for ok {
+			sum.add(conv(value))
 			n++
+
+			if item, ok = next(); ok {
+				value = selector(item)
+			}
 		}
+
+		r = sum.result()
 	}
 
 	return r / float64(n)
 }
 
+// AverageByT is the typed version of AverageBy.
+//
+//   - selectorFn is of type "func(TSource) TNumber"
+//
+// NOTE: AverageBy has better performance than AverageByT.
+func (q Query) AverageByT(selectorFn any) float64 {
+	selectorGenericFunc, err := newGenericFunc(
+		"AverageByT", "selectorFn", selectorFn,
+		simpleParamValidator(newElemTypeSlice(new(genericType)), newElemTypeSlice(new(genericType))),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	selectorFunc := func(item any) any {
+		return selectorGenericFunc.Call(item)
+	}
+
+	return q.AverageBy(selectorFunc)
+}
+
 // Contains determines whether a collection contains a specified element.
 func (q Query) Contains(value any) bool {
 	for item := range q.Iterate {
@@ -375,6 +429,83 @@ func (q Query) Min() any {
 	return r
 }
 
+// MaxBy returns the element of a collection for which a transform function
+// returns the maximum value. If several elements have the maximum value, the
+// first one is returned. It returns nil if the collection is empty.
+func (q Query) MaxBy(selector func(any) any) any {
+	return q.extremeBy(selector, 1)
+}
+
+// MaxByT is the typed version of MaxBy.
+//
+//   - selectorFn is of type "func(TSource) TKey"
+//
+// NOTE: MaxBy has better performance than MaxByT.
+func (q Query) MaxByT(selectorFn any) any {
+	selectorGenericFunc, err := newGenericFunc(
+		"MaxByT", "selectorFn", selectorFn,
+		simpleParamValidator(newElemTypeSlice(new(genericType)), newElemTypeSlice(new(genericType))),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	selectorFunc := func(item any) any {
+		return selectorGenericFunc.Call(item)
+	}
+
+	return q.MaxBy(selectorFunc)
+}
+
+// MinBy returns the element of a collection for which a transform function
+// returns the minimum value. If several elements have the minimum value, the
+// first one is returned. It returns nil if the collection is empty.
+func (q Query) MinBy(selector func(any) any) any {
+	return q.extremeBy(selector, -1)
+}
+
+// MinByT is the typed version of MinBy.
+//
+//   - selectorFn is of type "func(TSource) TKey"
+//
+// NOTE: MinBy has better performance than MinByT.
+func (q Query) MinByT(selectorFn any) any {
+	selectorGenericFunc, err := newGenericFunc(
+		"MinByT", "selectorFn", selectorFn,
+		simpleParamValidator(newElemTypeSlice(new(genericType)), newElemTypeSlice(new(genericType))),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	selectorFunc := func(item any) any {
+		return selectorGenericFunc.Call(item)
+	}
+
+	return q.MinBy(selectorFunc)
+}
+
+// extremeBy returns the first element whose key compares to the keys of all
+// the other elements with the given sign or zero.
+func (q Query) extremeBy(selector func(any) any, sign int) (r any) {
+	var compare comparer
+	var best any
+	for item := range q.Iterate {
+		key := selector(item)
+		if compare == nil {
+			compare = getComparer(key)
+			r, best = item, key
+			continue
+		}
+
+		if compare(key, best)*sign > 0 {
+			r, best = item, key
+		}
+	}
+
+	return
+}
+
 // Results collects all items from a query into a slice.
 func (q Query) Results() []any {
 	return slices.Collect(q.Iterate)
@@ -527,6 +658,174 @@ func (q Query) SumFloats() (r float64) {
 	return
 }
 
+// SumIntsChecked computes the sum of a collection of numeric values like
+// SumInts, but returns ErrOverflow instead of silently wrapping around if the
+// sum doesn't fit in an int64.
+func (q Query) SumIntsChecked() (r int64, err error) {
+	var conv intConverter
+	for item := range q.Iterate {
+		if conv == nil {
+			conv = getIntConverter(item)
+		}
+
+		x := conv(item)
+		sum := r + x
+		if (x > 0 && sum < r) || (x < 0 && sum > r) {
+			return 0, ErrOverflow
+		}
+		r = sum
+	}
+
+	return r, nil
+}
+
+// SumUIntsChecked computes the sum of a collection of numeric values like
+// SumUInts, but returns ErrOverflow instead of silently wrapping around if the
+// sum doesn't fit in an uint64.
+func (q Query) SumUIntsChecked() (r uint64, err error) {
+	var conv uintConverter
+	for item := range q.Iterate {
+		if conv == nil {
+			conv = getUIntConverter(item)
+		}
+
+		var carry uint64
+		r, carry = bits.Add64(r, conv(item), 0)
+		if carry != 0 {
+			return 0, ErrOverflow
+		}
+	}
+
+	return r, nil
+}
+
+// SumFloatsPrecise computes the sum of a collection of numeric values like
+// SumFloats, but uses Kahan-Neumaier compensated summation, so the rounding
+// error doesn't grow with the number of elements.
+func (q Query) SumFloatsPrecise() float64 {
+	return q.SumFloatsBy(identity)
+}
+
+// SumIntsBy computes the sum of the integer values obtained by invoking a
+// transform function on each element of a collection.
+//
+// Values can be of any integer type: int, int8, int16, int32, int64. The result
+// is int64. Method returns zero if the collection contains no elements.
+func (q Query) SumIntsBy(selector func(any) any) (r int64) {
+	var conv intConverter
+	for item := range q.Iterate {
+		value := selector(item)
+		if conv == nil {
+			conv = getIntConverter(value)
+		}
+		r += conv(value)
+	}
+
+	return
+}
+
+// SumIntsByT is the typed version of SumIntsBy.
+//
+//   - selectorFn is of type "func(TSource) TInt"
+//
+// NOTE: SumIntsBy has better performance than SumIntsByT.
+func (q Query) SumIntsByT(selectorFn any) int64 {
+	selectorGenericFunc, err := newGenericFunc(
+		"SumIntsByT", "selectorFn", selectorFn,
+		simpleParamValidator(newElemTypeSlice(new(genericType)), newElemTypeSlice(new(genericType))),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	selectorFunc := func(item any) any {
+		return selectorGenericFunc.Call(item)
+	}
+
+	return q.SumIntsBy(selectorFunc)
+}
+
+// SumUIntsBy computes the sum of the unsigned integer values obtained by
+// invoking a transform function on each element of a collection.
+//
+// Values can be of any unsigned integer type: uint, uint8, uint16, uint32,
+// uint64. The result is uint64. Method returns zero if the collection contains
+// no elements.
+func (q Query) SumUIntsBy(selector func(any) any) (r uint64) {
+	var conv uintConverter
+	for item := range q.Iterate {
+		value := selector(item)
+		if conv == nil {
+			conv = getUIntConverter(value)
+		}
+		r += conv(value)
+	}
+
+	return
+}
+
+// SumUIntsByT is the typed version of SumUIntsBy.
+//
+//   - selectorFn is of type "func(TSource) TUInt"
+//
+// NOTE: SumUIntsBy has better performance than SumUIntsByT.
+func (q Query) SumUIntsByT(selectorFn any) uint64 {
+	selectorGenericFunc, err := newGenericFunc(
+		"SumUIntsByT", "selectorFn", selectorFn,
+		simpleParamValidator(newElemTypeSlice(new(genericType)), newElemTypeSlice(new(genericType))),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	selectorFunc := func(item any) any {
+		return selectorGenericFunc.Call(item)
+	}
+
+	return q.SumUIntsBy(selectorFunc)
+}
+
+// SumFloatsBy computes the sum of the float values obtained by invoking a
+// transform function on each element of a collection, using Kahan-Neumaier
+// compensated summation.
+//
+// Values can be of any float type: float32 or float64. The result is float64.
+// Method returns zero if the collection contains no elements.
+func (q Query) SumFloatsBy(selector func(any) any) float64 {
+	var conv floatConverter
+	var sum neumaierSum
+	for item := range q.Iterate {
+		value := selector(item)
+		if conv == nil {
+			conv = getFloatConverter(value)
+		}
+		sum.add(conv(value))
+	}
+
+	return sum.result()
+}
+
+// SumFloatsByT is the typed version of SumFloatsBy.
+//
+//   - selectorFn is of type "func(TSource) TFloat"
+//
+// NOTE: SumFloatsBy has better performance than SumFloatsByT.
+func (q Query) SumFloatsByT(selectorFn any) float64 {
+	selectorGenericFunc, err := newGenericFunc(
+		"SumFloatsByT", "selectorFn", selectorFn,
+		simpleParamValidator(newElemTypeSlice(new(genericType)), newElemTypeSlice(new(genericType))),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	selectorFunc := func(item any) any {
+		return selectorGenericFunc.Call(item)
+	}
+
+	return q.SumFloatsBy(selectorFunc)
+}
+
 // ToChannel iterates over a collection and outputs each element to a channel,
 // then closes it.
 func (q Query) ToChannel(result chan<- any) {
@@ -667,3 +966,103 @@ func (q Query) ToSlice(v any) {
 	// Point v to the final slice (which may have a new backing array).
 	ptrValue.Elem().Set(out)
 }
+
+// int128 is a signed 128-bit accumulator used to sum int64 values without
+// overflowing.
+type int128 struct {
+	hi int64
+	lo uint64
+}
+
+func (s *int128) add(x int64) {
+	var carry uint64
+	s.lo, carry = bits.Add64(s.lo, uint64(x), 0)
+	s.hi += x>>63 + int64(carry)
+}
+
+func (s int128) float64() float64 {
+	u, negative := s.abs()
+	if negative {
+		return -u.float64()
+	}
+	return u.float64()
+}
+
+// quo returns s / n truncated toward zero. The quotient must fit in an int64,
+// which is the case when s is the sum of n int64 values.
+func (s int128) quo(n uint64) int64 {
+	u, negative := s.abs()
+	if negative {
+		return -int64(u.quo(n))
+	}
+	return int64(u.quo(n))
+}
+
+// abs returns the absolute value of s, and whether s is negative. Converting
+// the two halves of a negative value separately would cancel out its low
+// bits, e.g. for -1, whose lo is rounded up to 2^64 by float64.
+func (s int128) abs() (uint128, bool) {
+	if s.hi >= 0 {
+		return uint128{hi: uint64(s.hi), lo: s.lo}, false
+	}
+
+	lo, borrow := bits.Sub64(0, s.lo, 0)
+	hi, _ := bits.Sub64(0, uint64(s.hi), borrow)
+	return uint128{hi: hi, lo: lo}, true
+}
+
+// uint128 is an unsigned 128-bit accumulator used to sum uint64 values
+// without overflowing.
+type uint128 struct {
+	hi uint64
+	lo uint64
+}
+
+func (s *uint128) add(x uint64) {
+	var carry uint64
+	s.lo, carry = bits.Add64(s.lo, x, 0)
+	s.hi += carry
+}
+
+func (s uint128) float64() float64 {
+	return float64(s.hi)*0x1p64 + float64(s.lo)
+}
+
+// quo returns s / n truncated toward zero. The quotient must fit in a uint64,
+// which is the case when s is the sum of n uint64 values.
+func (s uint128) quo(n uint64) uint64 {
+	q, _ := bits.Div64(s.hi, s.lo, n)
+	return q
+}
+
+// neumaierSum accumulates float64 values with Kahan-Neumaier compensated
+// summation, which keeps track of the low-order bits lost by every addition.
+// Once the sum is infinite or NaN, e.g. after an infinite value or an
+// overflow, it is no longer compensated, and it is the result as is.
+type neumaierSum struct {
+	sum          float64
+	compensation float64
+}
+
+func (s *neumaierSum) add(x float64) {
+	t := s.sum + x
+	if math.IsInf(t, 0) || math.IsNaN(t) {
+		// The compensation would be Inf - Inf, i.e. NaN.
+		s.sum = t
+		return
+	}
+
+	if math.Abs(s.sum) >= math.Abs(x) {
+		s.compensation += (s.sum - t) + x
+	} else {
+		s.compensation += (x - t) + s.sum
+	}
+	s.sum = t
+}
+
+func (s neumaierSum) result() float64 {
+	if math.IsInf(s.sum, 0) || math.IsNaN(s.sum) {
+		return s.sum
+	}
+	return s.sum + s.compensation
+}
@@ -98,6 +98,76 @@ func TestAverageForNaN(t *testing.T) {
 	}
 }
 
+func TestAverage_NoOverflow(t *testing.T) {
+	tests := []struct {
+		input any
+		want  float64
+	}{
+		{[]int64{math.MaxInt64, math.MaxInt64, math.MaxInt64}, math.MaxInt64},
+		{[]int64{math.MinInt64, math.MinInt64}, math.MinInt64},
+		{[]uint64{math.MaxUint64, math.MaxUint64}, math.MaxUint64},
+		{[]int{-5}, -5},
+		{[]int{-1, -2, -3}, -2},
+		{[]int{3, -10}, -3.5},
+		{[]int8{-128, 127, -1}, -2. / 3},
+		{[]int64{math.MinInt64, math.MaxInt64}, -0.5},
+	}
+
+	for _, test := range tests {
+		if r := From(test.input).Average(); r != test.want {
+			t.Errorf("From(%v).Average()=%v expected %v", test.input, r, test.want)
+		}
+	}
+}
+
+func TestAverage_NonFinite(t *testing.T) {
+	inf := math.Inf(1)
+
+	tests := []struct {
+		input any
+		want  float64
+	}{
+		{[]float64{1, inf, 2}, inf},
+		{[]float64{1, -inf, 2}, -inf},
+		{[]float64{1, inf, -inf}, math.NaN()},
+		{[]float64{1, math.NaN(), 2}, math.NaN()},
+		{[]float64{1e308, 1e308}, inf},
+		{[]float32{1, float32(inf)}, inf},
+	}
+
+	for _, test := range tests {
+		r := From(test.input).Average()
+		if r != test.want && !(math.IsNaN(r) && math.IsNaN(test.want)) {
+			t.Errorf("From(%v).Average()=%v expected %v", test.input, r, test.want)
+		}
+	}
+}
+
+func TestAverageBy(t *testing.T) {
+	input := []foo{{f1: 1}, {f1: 2}, {f1: 6}}
+	calls := 0
+
+	if r := From(input).AverageBy(func(i any) any {
+		calls++
+		return i.(foo).f1
+	}); r != 3 {
+		t.Errorf("From(%v).AverageBy()=%v expected 3", input, r)
+	}
+	if calls != len(input) {
+		t.Errorf("From(%v).AverageBy() called selector %d times expected %d", input, calls, len(input))
+	}
+
+	if r := From(input).AverageByT(func(f foo) float32 { return float32(f.f1) / 2 }); r != 1.5 {
+		t.Errorf("From(%v).AverageByT()=%v expected 1.5", input, r)
+	}
+}
+
+func TestAverageByT_PanicWhenSelectorFnIsInvalid(t *testing.T) {
+	mustPanicWithError(t, "AverageByT: parameter [selectorFn] has a invalid function signature. Expected: 'func(T)T', actual: 'func(int,int)int'", func() {
+		From([]int{1, 2}).AverageByT(func(i, j int) int { return i })
+	})
+}
+
 func TestContains(t *testing.T) {
 	tests := []struct {
 		input any
@@ -325,6 +395,43 @@ func TestMin(t *testing.T) {
 	}
 }
 
+func TestMaxBy(t *testing.T) {
+	input := []foo{{f1: 3, f3: "a"}, {f1: 7, f3: "b"}, {f1: 7, f3: "c"}, {f1: 1, f3: "d"}}
+
+	if r := From(input).MaxBy(func(i any) any { return i.(foo).f1 }); r != input[1] {
+		t.Errorf("From(%v).MaxBy()=%v expected %v", input, r, input[1])
+	}
+	if r := From(input).MaxByT(func(f foo) string { return f.f3 }); r != input[3] {
+		t.Errorf("From(%v).MaxByT()=%v expected %v", input, r, input[3])
+	}
+	if r := From([]foo{}).MaxBy(func(i any) any { return i.(foo).f1 }); r != nil {
+		t.Errorf("From([]).MaxBy()=%v expected nil", r)
+	}
+}
+
+func TestMaxByT_PanicWhenSelectorFnIsInvalid(t *testing.T) {
+	mustPanicWithError(t, "MaxByT: parameter [selectorFn] has a invalid function signature. Expected: 'func(T)T', actual: 'func(int,int)int'", func() {
+		From([]int{1, 2}).MaxByT(func(i, j int) int { return i })
+	})
+}
+
+func TestMinBy(t *testing.T) {
+	input := []foo{{f1: 3, f3: "a"}, {f1: 1, f3: "b"}, {f1: 1, f3: "c"}, {f1: 7, f3: "d"}}
+
+	if r := From(input).MinBy(func(i any) any { return i.(foo).f1 }); r != input[1] {
+		t.Errorf("From(%v).MinBy()=%v expected %v", input, r, input[1])
+	}
+	if r := From(input).MinByT(func(f foo) string { return f.f3 }); r != input[0] {
+		t.Errorf("From(%v).MinByT()=%v expected %v", input, r, input[0])
+	}
+}
+
+func TestMinByT_PanicWhenSelectorFnIsInvalid(t *testing.T) {
+	mustPanicWithError(t, "MinByT: parameter [selectorFn] has a invalid function signature. Expected: 'func(T)T', actual: 'func(int,int)int'", func() {
+		From([]int{1, 2}).MinByT(func(i, j int) int { return i })
+	})
+}
+
 func TestResults(t *testing.T) {
 	input := []int{1, 2, 3}
 	want := []any{1, 2, 3}
@@ -446,6 +553,133 @@ func TestSumFloats(t *testing.T) {
 	}
 }
 
+func TestSumIntsChecked(t *testing.T) {
+	tests := []struct {
+		input any
+		want  int64
+		err   error
+	}{
+		{[]int{1, 2, 2, 3, 1}, 9, nil},
+		{[]int8{-100, -100}, -200, nil},
+		{[]int{}, 0, nil},
+		{[]int64{math.MaxInt64, -1, 1}, math.MaxInt64, nil},
+		{[]int64{math.MaxInt64, 1, -1}, 0, ErrOverflow},
+		{[]int64{math.MinInt64, -1}, 0, ErrOverflow},
+	}
+
+	for _, test := range tests {
+		if r, err := From(test.input).SumIntsChecked(); r != test.want || err != test.err {
+			t.Errorf("From(%v).SumIntsChecked()=%v,%v expected %v,%v", test.input, r, err, test.want, test.err)
+		}
+	}
+}
+
+func TestSumUIntsChecked(t *testing.T) {
+	tests := []struct {
+		input any
+		want  uint64
+		err   error
+	}{
+		{[]uint{1, 2, 2, 3, 1}, 9, nil},
+		{[]uint8{200, 200}, 400, nil},
+		{[]uint64{math.MaxUint64, 0}, math.MaxUint64, nil},
+		{[]uint64{math.MaxUint64, 1}, 0, ErrOverflow},
+	}
+
+	for _, test := range tests {
+		if r, err := From(test.input).SumUIntsChecked(); r != test.want || err != test.err {
+			t.Errorf("From(%v).SumUIntsChecked()=%v,%v expected %v,%v", test.input, r, err, test.want, test.err)
+		}
+	}
+}
+
+func TestSumFloatsPrecise(t *testing.T) {
+	tests := []struct {
+		input any
+		want  float64
+	}{
+		{[]float32{1., 2., 2., 3., 1.}, 9.},
+		{[]float64{}, 0.},
+		{[]float64{1, 1e100, 1, -1e100}, 2},
+		{Repeat(0.1, 10).Results(), 1},
+	}
+
+	for _, test := range tests {
+		if r := From(test.input).SumFloatsPrecise(); r != test.want {
+			t.Errorf("From(%v).SumFloatsPrecise()=%v expected %v", test.input, r, test.want)
+		}
+	}
+}
+
+func TestSumFloatsPrecise_NonFinite(t *testing.T) {
+	inf := math.Inf(1)
+
+	tests := []struct {
+		input []float64
+		want  float64
+	}{
+		{[]float64{1, inf, 2}, inf},
+		{[]float64{-inf, 1e100, 1}, -inf},
+		{[]float64{inf, -inf}, math.NaN()},
+		{[]float64{math.NaN(), 1}, math.NaN()},
+		{[]float64{1e308, 1e308}, inf},
+		{[]float64{-1e308, -1e308, 1}, -inf},
+	}
+
+	for _, test := range tests {
+		r := From(test.input).SumFloatsPrecise()
+		if r != test.want && !(math.IsNaN(r) && math.IsNaN(test.want)) {
+			t.Errorf("From(%v).SumFloatsPrecise()=%v expected %v", test.input, r, test.want)
+		}
+
+		r = From(test.input).SumFloatsBy(func(i any) any { return i })
+		if r != test.want && !(math.IsNaN(r) && math.IsNaN(test.want)) {
+			t.Errorf("From(%v).SumFloatsBy()=%v expected %v", test.input, r, test.want)
+		}
+	}
+}
+
+func TestSumBy(t *testing.T) {
+	input := []foo{{f1: 1, f3: "a"}, {f1: 2, f3: "bc"}, {f1: 3, f3: "def"}}
+
+	if r := From(input).SumIntsBy(func(i any) any { return i.(foo).f1 }); r != 6 {
+		t.Errorf("From(%v).SumIntsBy()=%v expected 6", input, r)
+	}
+	if r := From(input).SumIntsByT(func(f foo) int8 { return int8(len(f.f3)) }); r != 6 {
+		t.Errorf("From(%v).SumIntsByT()=%v expected 6", input, r)
+	}
+	if r := From(input).SumUIntsBy(func(i any) any { return uint(i.(foo).f1) }); r != 6 {
+		t.Errorf("From(%v).SumUIntsBy()=%v expected 6", input, r)
+	}
+	if r := From(input).SumUIntsByT(func(f foo) uint16 { return uint16(f.f1) }); r != 6 {
+		t.Errorf("From(%v).SumUIntsByT()=%v expected 6", input, r)
+	}
+	if r := From(input).SumFloatsBy(func(i any) any { return float64(i.(foo).f1) / 2 }); r != 3 {
+		t.Errorf("From(%v).SumFloatsBy()=%v expected 3", input, r)
+	}
+	if r := From(input).SumFloatsByT(func(f foo) float32 { return float32(f.f1) }); r != 6 {
+		t.Errorf("From(%v).SumFloatsByT()=%v expected 6", input, r)
+	}
+}
+
+func TestSumIntsByT_PanicWhenSelectorFnIsInvalid(t *testing.T) {
+	mustPanicWithError(t, "SumIntsByT: parameter [selectorFn] has a invalid function signature. Expected: 'func(T)T', actual: 'func(int,int)int'", func() {
+		From([]int{1, 2}).SumIntsByT(func(i, j int) int { return i })
+	})
+}
+
+func TestSumUIntsByT_PanicWhenSelectorFnIsInvalid(t *testing.T) {
+	mustPanicWithError(t, "SumUIntsByT: parameter [selectorFn] has a invalid function signature. Expected: 'func(T)T', actual: 'func(int,int)int'", func() {
+		From([]int{1, 2}).SumUIntsByT(func(i, j int) int { return i })
+	})
+}
+
+func TestSumFloatsByT_PanicWhenSelectorFnIsInvalid(t *testing.T) {
+	mustPanicWithError(t, "SumFloatsByT: parameter [selectorFn] has a invalid function signature. Expected: 'func(T)T', actual: 'func(int,int)int'", func() {
+		From([]int{1, 2}).SumFloatsByT(func(i, j int) int { return i })
+	})
+}
+
 func TestToChannel(t *testing.T) {
 	c := make(chan any)
 	input := []int{1, 2, 3, 4, 5}
@@ -0,0 +1,375 @@
+package linq
+
+import (
+	"math"
+	"time"
+)
+
+// RollingWindow describes the windows over which the rolling aggregates, such
+// as RollingSum or RollingMax, are computed. Every element of a collection
+// ends a window that contains it and some of the elements before it.
+//
+// Either Size or KeySelector has to be set:
+//
+//   - a window of Size elements contains the element and the Size-1 elements
+//     before it, or fewer at the beginning of the collection;
+//   - a key-based window contains the element and the elements before it whose
+//     key, as returned by KeySelector, is greater than the key of the element
+//     minus Range. Keys are either numbers, with a numeric Range, or
+//     time.Time values, with a time.Duration Range. The collection has to be
+//     sorted by key in ascending order.
+type RollingWindow struct {
+	Size        int
+	KeySelector func(any) any
+	Range       any
+}
+
+// Rolling is a type used to store the results of the rolling aggregates: an
+// element of a collection along with the aggregate of the window that ends
+// with it.
+type Rolling struct {
+	Value     any
+	Aggregate any
+}
+
+// RollingSum computes the sum of the numeric values in the windows of a
+// collection. It yields a Rolling for every element, with the float64 sum of
+// the window that ends with it as Aggregate.
+//
+// The sum is updated as elements enter and leave the window, with compensated
+// summation, so it takes O(1) time per element. Values can be of any integer,
+// unsigned integer or float type.
+func (q Query) RollingSum(window RollingWindow) Query {
+	return q.RollingSumBy(window, identity)
+}
+
+// RollingSumBy computes the sum of the numeric values obtained by invoking a
+// transform function on each element of a collection in its windows, like
+// RollingSum.
+func (q Query) RollingSumBy(window RollingWindow, selector func(any) any) Query {
+	return q.rolling("RollingSum", window, selector, func() rollingAggregate {
+		return &rollingSum{}
+	})
+}
+
+// RollingSumByT is the typed version of RollingSumBy.
+//
+//   - selectorFn is of type "func(TSource) TNumber"
+//
+// NOTE: RollingSumBy has better performance than RollingSumByT.
+func (q Query) RollingSumByT(window RollingWindow, selectorFn any) Query {
+	selectorGenericFunc, err := newGenericFunc(
+		"RollingSumByT", "selectorFn", selectorFn,
+		simpleParamValidator(newElemTypeSlice(new(genericType)), newElemTypeSlice(new(genericType))),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	selectorFunc := func(item any) any {
+		return selectorGenericFunc.Call(item)
+	}
+
+	return q.RollingSumBy(window, selectorFunc)
+}
+
+// RollingAverage computes the average of the numeric values in the windows of
+// a collection. It yields a Rolling for every element, with the float64
+// average of the window that ends with it as Aggregate.
+//
+// Like RollingSum, it takes O(1) time per element.
+func (q Query) RollingAverage(window RollingWindow) Query {
+	return q.RollingAverageBy(window, identity)
+}
+
+// RollingAverageBy computes the average of the numeric values obtained by
+// invoking a transform function on each element of a collection in its
+// windows, like RollingAverage.
+func (q Query) RollingAverageBy(window RollingWindow,
+	selector func(any) any) Query {
+	return q.rolling("RollingAverage", window, selector, func() rollingAggregate {
+		return &rollingSum{average: true}
+	})
+}
+
+// RollingAverageByT is the typed version of RollingAverageBy.
+//
+//   - selectorFn is of type "func(TSource) TNumber"
+//
+// NOTE: RollingAverageBy has better performance than RollingAverageByT.
+func (q Query) RollingAverageByT(window RollingWindow, selectorFn any) Query {
+	selectorGenericFunc, err := newGenericFunc(
+		"RollingAverageByT", "selectorFn", selectorFn,
+		simpleParamValidator(newElemTypeSlice(new(genericType)), newElemTypeSlice(new(genericType))),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	selectorFunc := func(item any) any {
+		return selectorGenericFunc.Call(item)
+	}
+
+	return q.RollingAverageBy(window, selectorFunc)
+}
+
+// RollingMin computes the minimum value in the windows of a collection. It
+// yields a Rolling for every element, with the minimum value of the window
+// that ends with it as Aggregate.
+//
+// The values are compared like in Min. They are kept in a monotonic deque, so
+// RollingMin takes O(1) amortized time per element.
+func (q Query) RollingMin(window RollingWindow) Query {
+	return q.RollingMinBy(window, identity)
+}
+
+// RollingMinBy computes the minimum of the values obtained by invoking a
+// transform function on each element of a collection in its windows, like
+// RollingMin.
+func (q Query) RollingMinBy(window RollingWindow, selector func(any) any) Query {
+	return q.rolling("RollingMin", window, selector, func() rollingAggregate {
+		return &rollingExtreme{sign: -1}
+	})
+}
+
+// RollingMinByT is the typed version of RollingMinBy.
+//
+//   - selectorFn is of type "func(TSource) TValue"
+//
+// NOTE: RollingMinBy has better performance than RollingMinByT.
+func (q Query) RollingMinByT(window RollingWindow, selectorFn any) Query {
+	selectorGenericFunc, err := newGenericFunc(
+		"RollingMinByT", "selectorFn", selectorFn,
+		simpleParamValidator(newElemTypeSlice(new(genericType)), newElemTypeSlice(new(genericType))),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	selectorFunc := func(item any) any {
+		return selectorGenericFunc.Call(item)
+	}
+
+	return q.RollingMinBy(window, selectorFunc)
+}
+
+// RollingMax computes the maximum value in the windows of a collection. It
+// yields a Rolling for every element, with the maximum value of the window
+// that ends with it as Aggregate.
+//
+// The values are compared like in Max. They are kept in a monotonic deque, so
+// RollingMax takes O(1) amortized time per element.
+func (q Query) RollingMax(window RollingWindow) Query {
+	return q.RollingMaxBy(window, identity)
+}
+
+// RollingMaxBy computes the maximum of the values obtained by invoking a
+// transform function on each element of a collection in its windows, like
+// RollingMax.
+func (q Query) RollingMaxBy(window RollingWindow, selector func(any) any) Query {
+	return q.rolling("RollingMax", window, selector, func() rollingAggregate {
+		return &rollingExtreme{sign: 1}
+	})
+}
+
+// RollingMaxByT is the typed version of RollingMaxBy.
+//
+//   - selectorFn is of type "func(TSource) TValue"
+//
+// NOTE: RollingMaxBy has better performance than RollingMaxByT.
+func (q Query) RollingMaxByT(window RollingWindow, selectorFn any) Query {
+	selectorGenericFunc, err := newGenericFunc(
+		"RollingMaxByT", "selectorFn", selectorFn,
+		simpleParamValidator(newElemTypeSlice(new(genericType)), newElemTypeSlice(new(genericType))),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	selectorFunc := func(item any) any {
+		return selectorGenericFunc.Call(item)
+	}
+
+	return q.RollingMaxBy(window, selectorFunc)
+}
+
+// rollingAggregate is an aggregate that is updated as the values enter and
+// leave a window. Values are numbered in the order they enter the window, and
+// leave it in the same order.
+type rollingAggregate interface {
+	push(seq int, value any)
+	pop(seq int, value any)
+	result() any
+}
+
+// rollingEntry is a value of a window along with its number and its key.
+type rollingEntry struct {
+	seq   int
+	value any
+	key   any
+}
+
+// rolling yields a Rolling for every element of the collection, with the
+// aggregate of the window that ends with it.
+func (q Query) rolling(methodName string, window RollingWindow,
+	selector func(any) any, newAggregate func() rollingAggregate) Query {
+	if (window.Size > 0) == (window.KeySelector != nil) {
+		panic(methodName + ": either Size or KeySelector must be set")
+	}
+
+	return Query{
+		Iterate: func(yield func(any) bool) {
+			aggregate := newAggregate()
+
+			var entries []rollingEntry
+			var expired func(oldest, newest any) bool
+			seq := 0
+
+			for item := range q.Iterate {
+				entry := rollingEntry{seq: seq, value: selector(item)}
+				seq++
+
+				if window.KeySelector != nil {
+					entry.key = window.KeySelector(item)
+					if expired == nil {
+						expired = newRangeExpiry(methodName, entry.key, window.Range)
+					}
+
+					for len(entries) > 0 && expired(entries[0].key, entry.key) {
+						aggregate.pop(entries[0].seq, entries[0].value)
+						entries = entries[1:]
+					}
+				} else if len(entries) == window.Size {
+					aggregate.pop(entries[0].seq, entries[0].value)
+					entries = entries[1:]
+				}
+
+				entries = append(entries, entry)
+				aggregate.push(entry.seq, entry.value)
+
+				if !yield(Rolling{Value: item, Aggregate: aggregate.result()}) {
+					return
+				}
+			}
+		},
+	}
+}
+
+// newRangeExpiry returns a function that reports whether the element with the
+// oldest key is out of the window that ends with the element with the newest
+// key.
+func newRangeExpiry(methodName string, key any, width any) func(oldest, newest any) bool {
+	if _, ok := key.(time.Time); ok {
+		d, ok := width.(time.Duration)
+		if !ok {
+			panic(methodName + ": Range must be a time.Duration for time.Time keys")
+		}
+
+		return func(oldest, newest any) bool {
+			return newest.(time.Time).Sub(oldest.(time.Time)) >= d
+		}
+	}
+
+	conv := getNumberConverter(key)
+	w := getNumberConverter(width)(width)
+	return func(oldest, newest any) bool {
+		return conv(newest)-conv(oldest) >= w
+	}
+}
+
+// rollingSum is the rollingAggregate of RollingSum and RollingAverage. The
+// infinite and NaN values of the window are counted apart from the
+// compensated sum of its finite values: removing them by adding their
+// negation would leave a NaN in the sum once they leave the window.
+type rollingSum struct {
+	average bool
+	conv    floatConverter
+	sum     neumaierSum
+	count   int
+
+	posInf, negInf, nan int
+}
+
+func (s *rollingSum) push(seq int, value any) {
+	if s.conv == nil {
+		s.conv = getNumberConverter(value)
+	}
+
+	s.count++
+	s.update(s.conv(value), 1)
+}
+
+func (s *rollingSum) pop(seq int, value any) {
+	s.count--
+	if s.count == 0 {
+		// Start over from an exact zero.
+		*s = rollingSum{average: s.average, conv: s.conv}
+		return
+	}
+
+	s.update(s.conv(value), -1)
+}
+
+// update adds the value x to the window if sign is 1, or removes it if sign
+// is -1.
+func (s *rollingSum) update(x float64, sign int) {
+	switch {
+	case math.IsNaN(x):
+		s.nan += sign
+	case math.IsInf(x, 1):
+		s.posInf += sign
+	case math.IsInf(x, -1):
+		s.negInf += sign
+	default:
+		s.sum.add(float64(sign) * x)
+	}
+}
+
+func (s *rollingSum) result() any {
+	var sum float64
+	switch {
+	case s.nan > 0 || s.posInf > 0 && s.negInf > 0:
+		sum = math.NaN()
+	case s.posInf > 0:
+		sum = math.Inf(1)
+	case s.negInf > 0:
+		sum = math.Inf(-1)
+	default:
+		sum = s.sum.result()
+	}
+
+	if s.average {
+		return sum / float64(s.count)
+	}
+	return sum
+}
+
+// rollingExtreme is the rollingAggregate of RollingMin and RollingMax. Its
+// deque holds the values of the window that are not dominated by a later
+// value, so the extreme value is always at its front.
+type rollingExtreme struct {
+	sign    int
+	compare comparer
+	deque   []rollingEntry
+}
+
+func (e *rollingExtreme) push(seq int, value any) {
+	if e.compare == nil {
+		e.compare = getComparer(value)
+	}
+
+	for len(e.deque) > 0 && e.compare(value, e.deque[len(e.deque)-1].value)*e.sign >= 0 {
+		e.deque = e.deque[:len(e.deque)-1]
+	}
+	e.deque = append(e.deque, rollingEntry{seq: seq, value: value})
+}
+
+func (e *rollingExtreme) pop(seq int, value any) {
+	if e.deque[0].seq == seq {
+		e.deque = e.deque[1:]
+	}
+}
+
+func (e *rollingExtreme) result() any {
+	return e.deque[0].value
+}
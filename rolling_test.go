@@ -0,0 +1,151 @@
+package linq
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+)
+
+func aggregates(q Query) (r []any) {
+	for item := range q.Iterate {
+		r = append(r, item.(Rolling).Aggregate)
+	}
+	return
+}
+
+func TestRollingSum(t *testing.T) {
+	input := []int{1, 2, 3, 4, 5}
+
+	tests := []struct {
+		size   int
+		output []any
+	}{
+		{1, []any{1.0, 2.0, 3.0, 4.0, 5.0}},
+		{2, []any{1.0, 3.0, 5.0, 7.0, 9.0}},
+		{3, []any{1.0, 3.0, 6.0, 9.0, 12.0}},
+		{10, []any{1.0, 3.0, 6.0, 10.0, 15.0}},
+	}
+
+	for _, test := range tests {
+		q := From(input).RollingSum(RollingWindow{Size: test.size})
+		if got := aggregates(q); !testQueryIteration(From(got), test.output) {
+			t.Errorf("From(%v).RollingSum(%d)=%v expected %v", input, test.size, got, test.output)
+		}
+	}
+}
+
+func TestRollingSumIsCompensated(t *testing.T) {
+	input := []float64{1e100, 1, -1e100, 1, 1, 1}
+	want := []any{1e100, 1e100, 1.0, -1e100, -1e100, 3.0}
+
+	q := From(input).RollingSum(RollingWindow{Size: 3})
+	if got := aggregates(q); !testQueryIteration(From(got), want) {
+		t.Errorf("From(%v).RollingSum(3)=%v expected %v", input, got, want)
+	}
+}
+
+func TestRollingSumWithNonFiniteValues(t *testing.T) {
+	inf, nan := math.Inf(1), math.NaN()
+
+	tests := []struct {
+		input   []float64
+		sums    []any
+		average []any
+	}{
+		{[]float64{1, inf, 2, 3, 4}, []any{1.0, inf, inf, 5.0, 7.0}, []any{1.0, inf, inf, 2.5, 3.5}},
+		{[]float64{inf, -inf, 1, 2}, []any{inf, nan, -inf, 3.0}, []any{inf, nan, -inf, 1.5}},
+		{[]float64{nan, 1, 2}, []any{nan, nan, 3.0}, []any{nan, nan, 1.5}},
+	}
+
+	// NaN values are compared by their formatting.
+	for _, test := range tests {
+		q := From(test.input).RollingSum(RollingWindow{Size: 2})
+		if got := aggregates(q); fmt.Sprint(got) != fmt.Sprint(test.sums) {
+			t.Errorf("From(%v).RollingSum(2)=%v expected %v", test.input, got, test.sums)
+		}
+
+		q = From(test.input).RollingAverage(RollingWindow{Size: 2})
+		if got := aggregates(q); fmt.Sprint(got) != fmt.Sprint(test.average) {
+			t.Errorf("From(%v).RollingAverage(2)=%v expected %v", test.input, got, test.average)
+		}
+	}
+}
+
+func TestRollingAverage(t *testing.T) {
+	input := []foo{{f1: 2}, {f1: 4}, {f1: 9}, {f1: 1}}
+	want := []any{2.0, 3.0, 6.5, 5.0}
+
+	q := From(input).RollingAverageByT(RollingWindow{Size: 2}, func(f foo) int { return f.f1 })
+	if got := aggregates(q); !testQueryIteration(From(got), want) {
+		t.Errorf("From(%v).RollingAverageByT(2)=%v expected %v", input, got, want)
+	}
+}
+
+func TestRollingMinMax(t *testing.T) {
+	input := []int{5, 3, 4, 1, 2, 6, 6, 0}
+
+	wantMin := []any{5, 3, 3, 1, 1, 1, 2, 0}
+	if got := aggregates(From(input).RollingMin(RollingWindow{Size: 3})); !testQueryIteration(From(got), wantMin) {
+		t.Errorf("From(%v).RollingMin(3)=%v expected %v", input, got, wantMin)
+	}
+
+	wantMax := []any{5, 5, 5, 4, 4, 6, 6, 6}
+	if got := aggregates(From(input).RollingMax(RollingWindow{Size: 3})); !testQueryIteration(From(got), wantMax) {
+		t.Errorf("From(%v).RollingMax(3)=%v expected %v", input, got, wantMax)
+	}
+}
+
+func TestRollingKeyRange(t *testing.T) {
+	type reading struct {
+		at    float64
+		value int
+	}
+
+	input := []reading{{0, 1}, {1, 2}, {2.5, 4}, {3, 8}, {6, 16}, {6, 32}}
+	window := RollingWindow{
+		KeySelector: func(i any) any { return i.(reading).at },
+		Range:       3,
+	}
+
+	want := []any{1.0, 3.0, 7.0, 14.0, 16.0, 48.0}
+	q := From(input).RollingSumByT(window, func(r reading) int { return r.value })
+	if got := aggregates(q); !testQueryIteration(From(got), want) {
+		t.Errorf("RollingSumByT(Range: 3)=%v expected %v", got, want)
+	}
+}
+
+func TestRollingTimeRange(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	minutes := []int{0, 2, 4, 5, 11}
+	window := RollingWindow{
+		KeySelector: func(i any) any { return start.Add(time.Duration(i.(int)) * time.Minute) },
+		Range:       5 * time.Minute,
+	}
+
+	want := []any{0, 0, 0, 2, 11}
+	if got := aggregates(From(minutes).RollingMin(window)); !testQueryIteration(From(got), want) {
+		t.Errorf("RollingMin(Range: 5m)=%v expected %v", got, want)
+	}
+}
+
+func TestRolling_PanicWhenWindowIsInvalid(t *testing.T) {
+	mustPanicWithError(t, "RollingSum: either Size or KeySelector must be set", func() {
+		From([]int{1}).RollingSum(RollingWindow{})
+	})
+	mustPanicWithError(t, "RollingMax: either Size or KeySelector must be set", func() {
+		From([]int{1}).RollingMax(RollingWindow{Size: 2, KeySelector: identity})
+	})
+}
+
+func TestRolling_PanicWhenRangeIsNotADuration(t *testing.T) {
+	mustPanicWithError(t, "RollingMin: Range must be a time.Duration for time.Time keys", func() {
+		From([]time.Time{{}}).RollingMin(RollingWindow{KeySelector: identity, Range: 5}).Results()
+	})
+}
+
+func TestRollingMaxByT_PanicWhenSelectorFnIsInvalid(t *testing.T) {
+	mustPanicWithError(t, "RollingMaxByT: parameter [selectorFn] has a invalid function signature. Expected: 'func(T)T', actual: 'func(int,int)int'", func() {
+		From([]int{1}).RollingMaxByT(RollingWindow{Size: 1}, func(i, j int) int { return i })
+	})
+}
@@ -0,0 +1,141 @@
+package linq
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// RowScanner scans the current row of rows, whose columns are named columns,
+// into an element of a FromRows query.
+type RowScanner func(rows *sql.Rows, columns []string) (any, error)
+
+// FromRows initializes a linq query that yields the rows of a database/sql
+// query, as scanned by scanner. ScanSlice, ScanMap and ScanStruct return
+// scanners for the common cases.
+//
+// The rows are closed when the iteration ends, including when it stops early,
+// e.g. with First or Take. The error of a scan, of rows.Err or of rows.Close
+// stops the iteration and is returned by the Err method of the query. Since
+// rows can only be read once, the query cannot be iterated again. A query that
+// is never iterated never closes rows, which holds on to their connection
+// until rows.Close is called.
+func FromRows(rows *sql.Rows, scanner RowScanner) SourceQuery {
+	return newSourceQuery(func(yield func(any) bool) (err error) {
+		defer func() {
+			if closeErr := rows.Close(); err == nil {
+				err = closeErr
+			}
+		}()
+
+		columns, err := rows.Columns()
+		if err != nil {
+			return err
+		}
+
+		for rows.Next() {
+			item, err := scanner(rows, columns)
+			if err != nil {
+				return err
+			}
+
+			if !yield(item) {
+				return nil
+			}
+		}
+
+		return rows.Err()
+	})
+}
+
+// ScanSlice is a RowScanner that scans a row into a []any with one value per
+// column, as returned by the driver.
+func ScanSlice(rows *sql.Rows, columns []string) (any, error) {
+	values := make([]any, len(columns))
+	dest := make([]any, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+
+	if err := rows.Scan(dest...); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// ScanMap is a RowScanner that scans a row into a map[string]any keyed by the
+// names of the columns, with the values returned by the driver.
+func ScanMap(rows *sql.Rows, columns []string) (any, error) {
+	values, err := ScanSlice(rows, columns)
+	if err != nil {
+		return nil, err
+	}
+
+	m := make(map[string]any, len(columns))
+	for i, column := range columns {
+		m[column] = values.([]any)[i]
+	}
+	return m, nil
+}
+
+// ScanStruct returns a RowScanner that scans a row into a struct of type T.
+// Each column is scanned into the field of the same name: the name given by
+// the `db` tag of the field, or else the name of the field, compared without
+// case. Fields tagged with `db:"-"` and columns without a field are ignored.
+// The nil pointers to the embedded structs of the fields are allocated. The
+// values are converted to the types of the fields by rows.Scan.
+//
+// ScanStruct panics if T is not a struct type.
+func ScanStruct[T any]() RowScanner {
+	t := reflect.TypeFor[T]()
+	if t.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("ScanStruct: unsupported type %v", t))
+	}
+
+	// fields maps the name of a column to the index of its field.
+	fields := make(map[string][]int)
+	for _, f := range reflect.VisibleFields(t) {
+		if !f.IsExported() || f.Anonymous {
+			continue
+		}
+
+		name := strings.ToLower(f.Name)
+		if tag, ok := f.Tag.Lookup("db"); ok {
+			if tag == "-" {
+				continue
+			}
+			if tag != "" {
+				name = tag
+			}
+		}
+
+		fields[name] = f.Index
+	}
+
+	return func(rows *sql.Rows, columns []string) (any, error) {
+		v := reflect.New(t).Elem()
+		dest := make([]any, len(columns))
+		for i, column := range columns {
+			index, ok := fields[column]
+			if !ok {
+				index, ok = fields[strings.ToLower(column)]
+			}
+
+			if ok {
+				f, err := allocFieldByIndex(v, index)
+				if err != nil {
+					return nil, fmt.Errorf("ScanStruct: column %q: %w", column, err)
+				}
+				dest[i] = f.Addr().Interface()
+			} else {
+				dest[i] = new(any)
+			}
+		}
+
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		return v.Interface(), nil
+	}
+}
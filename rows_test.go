@@ -0,0 +1,233 @@
+package linq
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeDriver is a database/sql driver whose queries return the fakeTable of
+// the same name.
+type fakeDriver struct{}
+
+type fakeTable struct {
+	columns []string
+	rows    [][]driver.Value
+	// err is returned once the rows are read, if it is not nil.
+	err error
+
+	mu     sync.Mutex
+	closed bool
+}
+
+var fakeTables = map[string]*fakeTable{}
+
+func init() {
+	sql.Register("linqfake", fakeDriver{})
+}
+
+func (fakeDriver) Open(name string) (driver.Conn, error) { return fakeConn{}, nil }
+
+type fakeConn struct{}
+
+func (fakeConn) Prepare(query string) (driver.Stmt, error) {
+	table, ok := fakeTables[query]
+	if !ok {
+		return nil, errors.New("fake: unknown table " + query)
+	}
+	return fakeStmt{table}, nil
+}
+
+func (fakeConn) Close() error              { return nil }
+func (fakeConn) Begin() (driver.Tx, error) { return nil, errors.New("fake: no transactions") }
+
+type fakeStmt struct{ table *fakeTable }
+
+func (s fakeStmt) Close() error  { return nil }
+func (s fakeStmt) NumInput() int { return 0 }
+
+func (s fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return nil, errors.New("fake: read only")
+}
+
+func (s fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.table.mu.Lock()
+	s.table.closed = false
+	s.table.mu.Unlock()
+	return &fakeRows{table: s.table}, nil
+}
+
+type fakeRows struct {
+	table *fakeTable
+	next  int
+}
+
+func (r *fakeRows) Columns() []string { return r.table.columns }
+
+func (r *fakeRows) Close() error {
+	r.table.mu.Lock()
+	defer r.table.mu.Unlock()
+	r.table.closed = true
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.next == len(r.table.rows) {
+		if r.table.err != nil {
+			return r.table.err
+		}
+		return io.EOF
+	}
+
+	copy(dest, r.table.rows[r.next])
+	r.next++
+	return nil
+}
+
+func (t *fakeTable) isClosed() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.closed
+}
+
+func queryFake(t *testing.T, name string, table *fakeTable) *sql.Rows {
+	t.Helper()
+
+	fakeTables[name] = table
+	db, err := sql.Open("linqfake", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	rows, err := db.Query(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rows
+}
+
+var joined = time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+func newUsersTable() *fakeTable {
+	return &fakeTable{
+		columns: []string{"id", "user_name", "Email", "joined", "ignored"},
+		rows: [][]driver.Value{
+			{int64(1), "ann", []byte("ann@example.com"), joined, "x"},
+			{int64(2), "bob", nil, joined.AddDate(0, 1, 0), "y"},
+			{int64(3), "cat", []byte("cat@example.com"), joined.AddDate(0, 2, 0), "z"},
+		},
+	}
+}
+
+type user struct {
+	ID     int64
+	Name   string `db:"user_name"`
+	Email  sql.NullString
+	Joined time.Time
+	Note   string `db:"-"`
+}
+
+func TestFromRows_Structs(t *testing.T) {
+	table := newUsersTable()
+	q := FromRows(queryFake(t, "users_structs", table), ScanStruct[user]())
+
+	want := []any{
+		user{1, "ann", sql.NullString{String: "ann@example.com", Valid: true}, joined, ""},
+		user{2, "bob", sql.NullString{}, joined.AddDate(0, 1, 0), ""},
+		user{3, "cat", sql.NullString{String: "cat@example.com", Valid: true}, joined.AddDate(0, 2, 0), ""},
+	}
+	if got := q.Results(); !reflect.DeepEqual(got, want) || q.Err() != nil {
+		t.Errorf("FromRows(ScanStruct[user]())=%v, %v expected %v", got, q.Err(), want)
+	}
+	if !table.isClosed() {
+		t.Errorf("FromRows(ScanStruct[user]()) didn't close the rows")
+	}
+}
+
+// UserAccount is exported so that it can be embedded as a settable pointer.
+type UserAccount struct {
+	ID     int64
+	Joined time.Time
+}
+
+type embeddedUser struct {
+	*UserAccount
+	Name string `db:"user_name"`
+}
+
+func TestFromRows_EmbeddedPointers(t *testing.T) {
+	q := FromRows(queryFake(t, "users_embedded", newUsersTable()), ScanStruct[embeddedUser]())
+
+	want := embeddedUser{&UserAccount{ID: 1, Joined: joined}, "ann"}
+	if got := q.First(); !reflect.DeepEqual(got, want) || q.Err() != nil {
+		t.Errorf("FromRows(ScanStruct[embeddedUser]()).First()=%v, %v expected %v", got, q.Err(), want)
+	}
+}
+
+func TestFromRows_Slices(t *testing.T) {
+	q := FromRows(queryFake(t, "users_slices", newUsersTable()), ScanSlice)
+
+	got := q.Results()
+	want := []any{int64(2), "bob", nil, joined.AddDate(0, 1, 0), "y"}
+	if len(got) != 3 || !reflect.DeepEqual(got[1], want) || q.Err() != nil {
+		t.Errorf("FromRows(ScanSlice)[1]=%v, %v expected %v", got[1], q.Err(), want)
+	}
+}
+
+func TestFromRows_Maps(t *testing.T) {
+	q := FromRows(queryFake(t, "users_maps", newUsersTable()), ScanMap)
+
+	first := q.First()
+	want := map[string]any{"id": int64(1), "user_name": "ann", "Email": []byte("ann@example.com"), "joined": joined, "ignored": "x"}
+	if !reflect.DeepEqual(first, want) || q.Err() != nil {
+		t.Errorf("FromRows(ScanMap).First()=%v, %v expected %v", first, q.Err(), want)
+	}
+}
+
+func TestFromRows_ClosesOnEarlyStop(t *testing.T) {
+	table := newUsersTable()
+	q := FromRows(queryFake(t, "users_early_stop", table), ScanSlice)
+
+	if first := q.First(); first == nil || q.Err() != nil {
+		t.Errorf("FromRows().First()=%v, %v expected a row", first, q.Err())
+	}
+	if !table.isClosed() {
+		t.Errorf("FromRows().First() didn't close the rows")
+	}
+}
+
+func TestFromRows_Errors(t *testing.T) {
+	errRows := errors.New("connection lost")
+	table := newUsersTable()
+	table.err = errRows
+
+	q := FromRows(queryFake(t, "users_error", table), ScanSlice)
+	if got := q.Results(); len(got) != 3 || q.Err() != errRows {
+		t.Errorf("FromRows()=%v, %v expected 3 rows and %v", got, q.Err(), errRows)
+	}
+
+	type badUser struct {
+		ID int64 `db:"user_name"`
+	}
+
+	table = newUsersTable()
+	q = FromRows(queryFake(t, "users_scan_error", table), ScanStruct[badUser]())
+	if got := q.Results(); got != nil || q.Err() == nil {
+		t.Errorf("FromRows(ScanStruct[badUser]())=%v, %v expected a scan error", got, q.Err())
+	}
+	if !table.isClosed() {
+		t.Errorf("FromRows(ScanStruct[badUser]()) didn't close the rows after an error")
+	}
+}
+
+func TestScanStruct_PanicWhenTypeIsNotAStruct(t *testing.T) {
+	mustPanicWithError(t, "ScanStruct: unsupported type string", func() {
+		ScanStruct[string]()
+	})
+}
@@ -0,0 +1,44 @@
+package linq
+
+import "sync"
+
+// SourceQuery is the type returned by the functions that initialize a query
+// from an external data source, such as FromReader, whose reads can fail.
+//
+// Like bufio.Scanner, the iteration of the query stops at the first error, and
+// Err has to be checked once the iteration is over to tell an error from the
+// end of the data.
+type SourceQuery struct {
+	Query
+	state *sourceState
+}
+
+type sourceState struct {
+	mu  sync.Mutex
+	err error
+}
+
+// Err returns the error that stopped the last iteration of the query, or nil
+// if there was none.
+func (s SourceQuery) Err() error {
+	s.state.mu.Lock()
+	defer s.state.mu.Unlock()
+	return s.state.err
+}
+
+// newSourceQuery returns a SourceQuery whose iteration calls iterate, which
+// returns the error that stopped it, if any.
+func newSourceQuery(iterate func(yield func(any) bool) error) SourceQuery {
+	s := SourceQuery{state: &sourceState{}}
+	s.Query = Query{
+		Iterate: func(yield func(any) bool) {
+			err := iterate(yield)
+
+			s.state.mu.Lock()
+			defer s.state.mu.Unlock()
+			s.state.err = err
+		},
+	}
+
+	return s
+}
@@ -0,0 +1,699 @@
+package linq
+
+import (
+	"bufio"
+	"container/heap"
+	"encoding/gob"
+	"errors"
+	"io"
+	"iter"
+	"os"
+	"sort"
+	"sync"
+)
+
+// Codec serializes the elements that a Spill writes to disk.
+type Codec interface {
+	// NewEncoder returns an Encoder that writes elements to w.
+	NewEncoder(w io.Writer) Encoder
+	// NewDecoder returns a Decoder that reads the elements written to r by an
+	// Encoder of the same codec.
+	NewDecoder(r io.Reader) Decoder
+}
+
+// Encoder writes a stream of elements.
+type Encoder interface {
+	Encode(item any) error
+}
+
+// Decoder reads a stream of elements. Decode returns io.EOF when there are no
+// more elements.
+type Decoder interface {
+	Decode() (any, error)
+}
+
+// GobCodec is a Codec that serializes elements with encoding/gob. It is the
+// default codec of Spill.
+//
+// Elements are encoded as interface values, so their concrete types have to be
+// registered with gob.Register, unless they are basic types such as int or
+// string. Only the exported fields of structs are serialized.
+type GobCodec struct{}
+
+// NewEncoder returns an Encoder that writes gob-encoded elements to w.
+func (GobCodec) NewEncoder(w io.Writer) Encoder {
+	return gobEncoder{gob.NewEncoder(w)}
+}
+
+// NewDecoder returns a Decoder that reads gob-encoded elements from r.
+func (GobCodec) NewDecoder(r io.Reader) Decoder {
+	return gobDecoder{gob.NewDecoder(r)}
+}
+
+type gobEncoder struct {
+	enc *gob.Encoder
+}
+
+func (e gobEncoder) Encode(item any) error {
+	return e.enc.Encode(&item)
+}
+
+type gobDecoder struct {
+	dec *gob.Decoder
+}
+
+func (d gobDecoder) Decode() (item any, err error) {
+	err = d.dec.Decode(&item)
+	return
+}
+
+// SpillOptions configures a Spill.
+type SpillOptions struct {
+	// MaxElements is the number of elements an operation keeps in memory
+	// before it writes them to disk. It has to be positive.
+	MaxElements int
+	// Dir is the directory in which the temporary files are created. If it is
+	// empty, the default directory for temporary files is used.
+	Dir string
+	// Codec serializes the elements written to disk. If it is nil, GobCodec
+	// is used.
+	Codec Codec
+}
+
+// Spill runs the operations that otherwise buffer their whole input in memory,
+// such as OrderBy, GroupBy, Reverse and Join, within a memory budget. Past
+// that budget, the elements are written to temporary files and read back when
+// they are needed:
+//
+//   - OrderBy and OrderByDescending sort runs of MaxElements elements, write
+//     each of them to a file and merge them;
+//   - GroupBy and Join partition the elements by key on disk, keeping only the
+//     distinct keys and a single group in memory;
+//   - Reverse writes chunks of MaxElements elements to files and reads them
+//     back in reverse order.
+//
+// Key selectors are invoked again on the elements read from disk, so they
+// have to return the same key every time they are called with an element.
+//
+// I/O and codec errors stop the iteration of the query. Like bufio.Scanner,
+// Spill records the first of them, and Err has to be checked once the
+// iteration is over. Close removes the temporary files.
+type Spill struct {
+	dir         string
+	maxElements int
+	codec       Codec
+
+	mu  sync.Mutex
+	err error
+}
+
+// NewSpill creates a Spill along with the directory that holds its temporary
+// files.
+func NewSpill(options SpillOptions) (*Spill, error) {
+	if options.MaxElements <= 0 {
+		return nil, errors.New("NewSpill: MaxElements must be positive")
+	}
+
+	codec := options.Codec
+	if codec == nil {
+		codec = GobCodec{}
+	}
+
+	dir, err := os.MkdirTemp(options.Dir, "linq-spill-")
+	if err != nil {
+		return nil, err
+	}
+
+	return &Spill{dir: dir, maxElements: options.MaxElements, codec: codec}, nil
+}
+
+// Err returns the first error that stopped the iteration of a query of the
+// Spill, or nil if there was none.
+func (s *Spill) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// Close removes the temporary files of the Spill. The queries of the Spill
+// cannot be iterated after Close.
+func (s *Spill) Close() error {
+	return os.RemoveAll(s.dir)
+}
+
+// OrderBy sorts the elements of a collection in ascending order according to
+// a key, like Query.OrderBy, with at most MaxElements elements in memory. The
+// sort is stable, and ThenBy and ThenByDescending can be applied to the
+// result.
+func (s *Spill) OrderBy(q Query, selector func(any) any) OrderedQuery {
+	return s.orderBy(q, []order{{selector: selector}})
+}
+
+// OrderByT is the typed version of OrderBy.
+//
+//   - selectorFn is of type "func(TSource) TKey"
+//
+// NOTE: OrderBy has better performance than OrderByT.
+func (s *Spill) OrderByT(q Query, selectorFn any) OrderedQuery {
+	selectorGenericFunc, err := newGenericFunc(
+		"OrderByT", "selectorFn", selectorFn,
+		simpleParamValidator(newElemTypeSlice(new(genericType)), newElemTypeSlice(new(genericType))),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	selectorFunc := func(item any) any {
+		return selectorGenericFunc.Call(item)
+	}
+
+	return s.OrderBy(q, selectorFunc)
+}
+
+// OrderByDescending sorts the elements of a collection in descending order
+// according to a key, like Query.OrderByDescending, with at most MaxElements
+// elements in memory.
+func (s *Spill) OrderByDescending(q Query, selector func(any) any) OrderedQuery {
+	return s.orderBy(q, []order{{selector: selector, desc: true}})
+}
+
+// OrderByDescendingT is the typed version of OrderByDescending.
+//
+//   - selectorFn is of type "func(TSource) TKey"
+//
+// NOTE: OrderByDescending has better performance than OrderByDescendingT.
+func (s *Spill) OrderByDescendingT(q Query, selectorFn any) OrderedQuery {
+	selectorGenericFunc, err := newGenericFunc(
+		"OrderByDescendingT", "selectorFn", selectorFn,
+		simpleParamValidator(newElemTypeSlice(new(genericType)), newElemTypeSlice(new(genericType))),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	selectorFunc := func(item any) any {
+		return selectorGenericFunc.Call(item)
+	}
+
+	return s.OrderByDescending(q, selectorFunc)
+}
+
+func (s *Spill) orderBy(q Query, orders []order) OrderedQuery {
+	return OrderedQuery{
+		orders:   orders,
+		original: q,
+		spill:    s,
+		Query: Query{
+			Iterate: func(yield func(any) bool) {
+				runs, ok := s.sortRuns(q, orders)
+				if !ok {
+					return
+				}
+
+				for item := range s.merge(runs) {
+					if !yield(item) {
+						return
+					}
+				}
+			},
+		},
+	}
+}
+
+// Reverse inverts the order of the elements in a collection, like
+// Query.Reverse, with at most MaxElements elements in memory.
+func (s *Spill) Reverse(q Query) Query {
+	return Query{
+		Iterate: func(yield func(any) bool) {
+			if s.Err() != nil {
+				return
+			}
+
+			var chunks []string
+			defer func() {
+				for _, chunk := range chunks {
+					os.Remove(chunk)
+				}
+			}()
+
+			var items []any
+			for item := range q.Iterate {
+				if len(items) == s.maxElements {
+					chunk, ok := s.writeRun(items)
+					if !ok {
+						return
+					}
+					chunks = append(chunks, chunk)
+					items = items[:0]
+				}
+				items = append(items, item)
+			}
+
+			for i := len(chunks); i >= 0; i-- {
+				if i < len(chunks) {
+					items = items[:0]
+					for item := range s.readRun(chunks[i]) {
+						items = append(items, item)
+					}
+					if s.Err() != nil {
+						return
+					}
+				}
+
+				for j := len(items) - 1; j >= 0; j-- {
+					if !yield(items[j]) {
+						return
+					}
+				}
+			}
+		},
+	}
+}
+
+// GroupBy groups the elements of a collection according to a specified key
+// selector function and projects the elements for each group by using a
+// specified function, like Query.GroupBy.
+//
+// Only the distinct keys and the elements of the group being yielded are kept
+// in memory. Unlike Query.GroupBy, the groups are yielded in the order their
+// keys are first seen.
+func (s *Spill) GroupBy(q Query, keySelector func(any) any,
+	elementSelector func(any) any) Query {
+	return Query{
+		Iterate: func(yield func(any) bool) {
+			keys := newSpillKeys(keySelector)
+			runs, ok := s.sortRuns(q, []order{{selector: keys.add}})
+			if !ok {
+				return
+			}
+
+			var group *Group
+			for item := range s.merge(runs) {
+				key := keySelector(item)
+				if group != nil && !keys.equal(group.Key, key) {
+					if !yield(*group) {
+						return
+					}
+					group = nil
+				}
+
+				if group == nil {
+					group = &Group{Key: key}
+				}
+				group.Group = append(group.Group, elementSelector(item))
+			}
+
+			if group != nil && s.Err() == nil {
+				yield(*group)
+			}
+		},
+	}
+}
+
+// GroupByT is the typed version of GroupBy.
+//
+//   - keySelectorFn is of type "func(TSource) TKey"
+//   - elementSelectorFn is of type "func(TSource) TElement"
+//
+// NOTE: GroupBy has better performance than GroupByT.
+func (s *Spill) GroupByT(q Query, keySelectorFn any,
+	elementSelectorFn any) Query {
+	keySelectorGenericFunc, err := newGenericFunc(
+		"GroupByT", "keySelectorFn", keySelectorFn,
+		simpleParamValidator(newElemTypeSlice(new(genericType)), newElemTypeSlice(new(genericType))),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	keySelectorFunc := func(item any) any {
+		return keySelectorGenericFunc.Call(item)
+	}
+
+	elementSelectorGenericFunc, err := newGenericFunc(
+		"GroupByT", "elementSelectorFn", elementSelectorFn,
+		simpleParamValidator(newElemTypeSlice(new(genericType)), newElemTypeSlice(new(genericType))),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	elementSelectorFunc := func(item any) any {
+		return elementSelectorGenericFunc.Call(item)
+	}
+
+	return s.GroupBy(q, keySelectorFunc, elementSelectorFunc)
+}
+
+// Join correlates the elements of two collections based on matching keys, like
+// Query.Join.
+//
+// Both collections are partitioned by key on disk, and only the distinct keys
+// of outer and the inner elements that match a single key are kept in memory.
+// Unlike Query.Join, the results are ordered by the key of the outer elements,
+// in the order these keys are first seen. For each key, the order of the outer
+// elements is preserved, and for each of these elements, the order of the
+// matching inner elements.
+func (s *Spill) Join(outer Query, inner Query,
+	outerKeySelector func(any) any,
+	innerKeySelector func(any) any,
+	resultSelector func(outer any, inner any) any) Query {
+	return Query{
+		Iterate: func(yield func(any) bool) {
+			keys := newSpillKeys(outerKeySelector)
+			outerRuns, ok := s.sortRuns(outer, []order{{selector: keys.add}})
+			if !ok {
+				return
+			}
+
+			innerIndex := func(item any) any {
+				return keys.index[innerKeySelector(item)]
+			}
+			matched := inner.Where(func(item any) bool {
+				_, ok := keys.index[innerKeySelector(item)]
+				return ok
+			})
+			innerRuns, ok := s.sortRuns(matched, []order{{selector: innerIndex}})
+			if !ok {
+				outerRuns.remove()
+				return
+			}
+
+			next, stop := iter.Pull(s.merge(innerRuns))
+			defer stop()
+			innerItem, innerOK := next()
+
+			current := -1
+			var group []any
+			for outerItem := range s.merge(outerRuns) {
+				if index := keys.index[outerKeySelector(outerItem)]; index != current {
+					current, group = index, group[:0]
+					for innerOK && innerIndex(innerItem).(int) == current {
+						group = append(group, innerItem)
+						innerItem, innerOK = next()
+					}
+				}
+
+				for _, innerItem := range group {
+					if !yield(resultSelector(outerItem, innerItem)) {
+						return
+					}
+				}
+			}
+		},
+	}
+}
+
+// JoinT is the typed version of Join.
+//
+//   - outerKeySelectorFn is of type "func(TOuter) TKey"
+//   - innerKeySelectorFn is of type "func(TInner) TKey"
+//   - resultSelectorFn is of type "func(TOuter,TInner) TResult"
+//
+// NOTE: Join has better performance than JoinT.
+func (s *Spill) JoinT(outer Query, inner Query,
+	outerKeySelectorFn any,
+	innerKeySelectorFn any,
+	resultSelectorFn any) Query {
+	outerKeySelectorGenericFunc, err := newGenericFunc(
+		"JoinT", "outerKeySelectorFn", outerKeySelectorFn,
+		simpleParamValidator(newElemTypeSlice(new(genericType)), newElemTypeSlice(new(genericType))),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	outerKeySelectorFunc := func(item any) any {
+		return outerKeySelectorGenericFunc.Call(item)
+	}
+
+	innerKeySelectorFuncGenericFunc, err := newGenericFunc(
+		"JoinT", "innerKeySelectorFn",
+		innerKeySelectorFn,
+		simpleParamValidator(newElemTypeSlice(new(genericType)), newElemTypeSlice(new(genericType))),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	innerKeySelectorFunc := func(item any) any {
+		return innerKeySelectorFuncGenericFunc.Call(item)
+	}
+
+	resultSelectorGenericFunc, err := newGenericFunc(
+		"JoinT", "resultSelectorFn", resultSelectorFn,
+		simpleParamValidator(newElemTypeSlice(new(genericType), new(genericType)), newElemTypeSlice(new(genericType))),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	resultSelectorFunc := func(outer any, inner any) any {
+		return resultSelectorGenericFunc.Call(outer, inner)
+	}
+
+	return s.Join(outer, inner, outerKeySelectorFunc, innerKeySelectorFunc, resultSelectorFunc)
+}
+
+// fail records err if it is the first error of the Spill.
+func (s *Spill) fail(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err == nil {
+		s.err = err
+	}
+}
+
+// spillKeys numbers the distinct keys of a collection in the order they are
+// first seen, which partitions the elements by key once they are sorted by
+// that number.
+type spillKeys struct {
+	selector func(any) any
+	index    map[any]int
+}
+
+func newSpillKeys(selector func(any) any) *spillKeys {
+	return &spillKeys{selector: selector, index: make(map[any]int)}
+}
+
+// add returns the number of the key of item, numbering the key if it wasn't
+// seen before.
+func (k *spillKeys) add(item any) any {
+	key := k.selector(item)
+	i, ok := k.index[key]
+	if !ok {
+		i = len(k.index)
+		k.index[key] = i
+	}
+	return i
+}
+
+func (k *spillKeys) equal(a, b any) bool {
+	return k.index[a] == k.index[b]
+}
+
+// spillRuns is a collection sorted by sortRuns: either the sorted elements
+// when they fit in memory, or the files of the sorted runs.
+type spillRuns struct {
+	h     *rankedHeap
+	items []any
+	files []string
+}
+
+// sortRuns sorts the collection by orders in runs of at most maxElements
+// elements, and writes each run to a file if the collection doesn't fit in
+// memory. It returns false if the Spill failed.
+func (s *Spill) sortRuns(q Query, orders []order) (*spillRuns, bool) {
+	if s.Err() != nil {
+		return nil, false
+	}
+
+	r := &spillRuns{h: &rankedHeap{orders: make([]order, len(orders))}}
+	copy(r.h.orders, orders)
+
+	var run []rankedItem
+	sortRun := func() {
+		sort.Slice(run, func(i, j int) bool {
+			return r.h.before(run[i], run[j])
+		})
+	}
+
+	index := 0
+	for item := range q.Iterate {
+		if len(run) == s.maxElements {
+			sortRun()
+			file, ok := s.writeRankedRun(run)
+			if !ok {
+				r.remove()
+				return nil, false
+			}
+			r.files = append(r.files, file)
+			run = run[:0]
+		}
+
+		ranked := r.rank(item, index)
+		if index == 0 {
+			for i := range r.h.orders {
+				r.h.orders[i].compare = getComparer(ranked.keys[i])
+			}
+		}
+		run = append(run, ranked)
+		index++
+	}
+
+	sortRun()
+	if len(r.files) == 0 {
+		r.items = make([]any, len(run))
+		for i, ranked := range run {
+			r.items[i] = ranked.item
+		}
+		return r, true
+	}
+
+	if len(run) > 0 {
+		file, ok := s.writeRankedRun(run)
+		if !ok {
+			r.remove()
+			return nil, false
+		}
+		r.files = append(r.files, file)
+	}
+
+	return r, true
+}
+
+func (r *spillRuns) rank(item any, index int) rankedItem {
+	ranked := rankedItem{item: item, keys: make([]any, len(r.h.orders)), index: index}
+	for i, o := range r.h.orders {
+		ranked.keys[i] = o.selector(item)
+	}
+	return ranked
+}
+
+func (r *spillRuns) remove() {
+	for _, file := range r.files {
+		os.Remove(file)
+	}
+}
+
+// mergeHeap is a rankedHeap whose root is the element sorted first.
+type mergeHeap struct {
+	*rankedHeap
+	next []func() (any, bool)
+}
+
+func (h mergeHeap) Less(i, j int) bool {
+	return h.before(h.items[i], h.items[j])
+}
+
+// merge returns the elements sorted by sortRuns, merging the runs written to
+// disk, and removes the files of the runs once they are read.
+func (s *Spill) merge(r *spillRuns) iter.Seq[any] {
+	return func(yield func(any) bool) {
+		if r.files == nil {
+			for _, item := range r.items {
+				if !yield(item) {
+					return
+				}
+			}
+			return
+		}
+
+		defer r.remove()
+
+		h := mergeHeap{
+			rankedHeap: &rankedHeap{orders: r.h.orders},
+			next:       make([]func() (any, bool), len(r.files)),
+		}
+		for i, file := range r.files {
+			next, stop := iter.Pull(s.readRun(file))
+			defer stop()
+
+			h.next[i] = next
+			if item, ok := next(); ok {
+				heap.Push(h, r.rank(item, i))
+			}
+		}
+
+		for h.Len() > 0 {
+			head := h.items[0]
+			if !yield(head.item) {
+				return
+			}
+
+			if item, ok := h.next[head.index](); ok {
+				h.items[0] = r.rank(item, head.index)
+				heap.Fix(h, 0)
+			} else {
+				heap.Pop(h)
+			}
+		}
+	}
+}
+
+func (s *Spill) writeRankedRun(run []rankedItem) (string, bool) {
+	items := make([]any, len(run))
+	for i, ranked := range run {
+		items[i] = ranked.item
+	}
+	return s.writeRun(items)
+}
+
+// writeRun writes items to a new temporary file and returns its name. It
+// returns false if the Spill failed.
+func (s *Spill) writeRun(items []any) (string, bool) {
+	f, err := os.CreateTemp(s.dir, "run-")
+	if err != nil {
+		s.fail(err)
+		return "", false
+	}
+
+	w := bufio.NewWriter(f)
+	enc := s.codec.NewEncoder(w)
+	for _, item := range items {
+		if err = enc.Encode(item); err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = w.Flush()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		os.Remove(f.Name())
+		s.fail(err)
+		return "", false
+	}
+	return f.Name(), true
+}
+
+// readRun returns the elements written to file by writeRun.
+func (s *Spill) readRun(file string) iter.Seq[any] {
+	return func(yield func(any) bool) {
+		f, err := os.Open(file)
+		if err != nil {
+			s.fail(err)
+			return
+		}
+		defer f.Close()
+
+		dec := s.codec.NewDecoder(bufio.NewReader(f))
+		for {
+			item, err := dec.Decode()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				s.fail(err)
+				return
+			}
+
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
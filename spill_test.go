@@ -0,0 +1,263 @@
+package linq
+
+import (
+	"io"
+	"os"
+	"reflect"
+	"testing"
+)
+
+// countingCodec is a GobCodec that counts the elements it encodes.
+type countingCodec struct {
+	GobCodec
+	encoded *int
+}
+
+func (c countingCodec) NewEncoder(w io.Writer) Encoder {
+	return countingEncoder{c.GobCodec.NewEncoder(w), c.encoded}
+}
+
+type countingEncoder struct {
+	Encoder
+	encoded *int
+}
+
+func (e countingEncoder) Encode(item any) error {
+	*e.encoded++
+	return e.Encoder.Encode(item)
+}
+
+func newTestSpill(t *testing.T, maxElements int) (*Spill, *int) {
+	t.Helper()
+
+	encoded := new(int)
+	s, err := NewSpill(SpillOptions{
+		MaxElements: maxElements,
+		Dir:         t.TempDir(),
+		Codec:       countingCodec{encoded: encoded},
+	})
+	if err != nil {
+		t.Fatalf("NewSpill()=%v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+
+	return s, encoded
+}
+
+func assertSpillDone(t *testing.T, s *Spill) {
+	t.Helper()
+
+	if err := s.Err(); err != nil {
+		t.Errorf("Err()=%v expected nil", err)
+	}
+	if entries, _ := os.ReadDir(s.dir); len(entries) != 0 {
+		t.Errorf("spill directory has %d files after iteration, expected 0", len(entries))
+	}
+}
+
+func TestNewSpill(t *testing.T) {
+	if _, err := NewSpill(SpillOptions{}); err == nil {
+		t.Errorf("NewSpill(SpillOptions{}) expected an error")
+	}
+
+	s, err := NewSpill(SpillOptions{MaxElements: 1, Dir: t.TempDir()})
+	if err != nil {
+		t.Fatalf("NewSpill()=%v", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Errorf("Close()=%v", err)
+	}
+	if _, err := os.Stat(s.dir); !os.IsNotExist(err) {
+		t.Errorf("Close() didn't remove %s", s.dir)
+	}
+}
+
+func TestSpill_OrderBy(t *testing.T) {
+	input := Range(1, 50).Select(func(i any) any { return (i.(int) * 37) % 53 }).Results()
+	key := func(i any) any { return i.(int) % 5 }
+
+	tests := []struct {
+		maxElements int
+		spilled     bool
+	}{
+		{4, true},
+		{7, true},
+		{50, false},
+		{100, false},
+	}
+
+	for _, test := range tests {
+		s, encoded := newTestSpill(t, test.maxElements)
+
+		want := From(input).OrderBy(key).ThenByDescending(identity).Results()
+		q := s.OrderBy(From(input), key).ThenByDescending(identity)
+		if !testQueryIteration(q.Query, want) {
+			t.Errorf("MaxElements=%d: OrderBy().ThenByDescending()=%v expected %v", test.maxElements, toSlice(q.Query), want)
+		}
+		if spilled := *encoded > 0; spilled != test.spilled {
+			t.Errorf("MaxElements=%d: spilled=%v expected %v", test.maxElements, spilled, test.spilled)
+		}
+		assertSpillDone(t, s)
+	}
+}
+
+func TestSpill_OrderByIsStable(t *testing.T) {
+	input := []string{"b1", "a1", "c1", "a2", "b2", "c2", "a3", "b3", "c3", "a4"}
+	want := []any{"c1", "c2", "c3", "b1", "b2", "b3", "a1", "a2", "a3", "a4"}
+
+	s, _ := newTestSpill(t, 3)
+	q := s.OrderByDescendingT(From(input), func(s string) byte { return s[0] })
+	if !testQueryIteration(q.Query, want) {
+		t.Errorf("OrderByDescendingT()=%v expected %v", toSlice(q.Query), want)
+	}
+	assertSpillDone(t, s)
+}
+
+func TestSpill_OrderByTake(t *testing.T) {
+	s, _ := newTestSpill(t, 3)
+	q := s.OrderBy(Range(1, 20).Reverse(), identity)
+
+	if r := q.Take(2); !testQueryIteration(r, []any{1, 2}) {
+		t.Errorf("OrderBy().Take(2)=%v expected [1 2]", toSlice(r))
+	}
+	if r := q.Take(5); !testQueryIteration(r, []any{1, 2, 3, 4, 5}) {
+		t.Errorf("OrderBy().Take(5)=%v expected [1 2 3 4 5]", toSlice(r))
+	}
+	assertSpillDone(t, s)
+}
+
+func TestSpill_OrderByT_PanicWhenSelectorFnIsInvalid(t *testing.T) {
+	s, _ := newTestSpill(t, 1)
+	mustPanicWithError(t, "OrderByT: parameter [selectorFn] has a invalid function signature. Expected: 'func(T)T', actual: 'func(int,int)int'", func() {
+		s.OrderByT(From([]int{1}), func(item, j int) int { return item })
+	})
+}
+
+func TestSpill_Reverse(t *testing.T) {
+	for _, maxElements := range []int{1, 3, 4, 10, 20} {
+		s, _ := newTestSpill(t, maxElements)
+
+		want := Range(1, 10).Reverse().Results()
+		if q := s.Reverse(Range(1, 10)); !testQueryIteration(q, want) {
+			t.Errorf("MaxElements=%d: Reverse()=%v expected %v", maxElements, toSlice(q), want)
+		}
+		assertSpillDone(t, s)
+	}
+}
+
+func TestSpill_GroupBy(t *testing.T) {
+	input := []string{"b1", "a1", "c1", "a2", "b2", "a3", "d1", "b3"}
+	want := []any{
+		Group{Key: byte('b'), Group: []any{"1", "2", "3"}},
+		Group{Key: byte('a'), Group: []any{"1", "2", "3"}},
+		Group{Key: byte('c'), Group: []any{"1"}},
+		Group{Key: byte('d'), Group: []any{"1"}},
+	}
+
+	for _, maxElements := range []int{2, 3, 100} {
+		s, _ := newTestSpill(t, maxElements)
+
+		q := s.GroupByT(From(input),
+			func(s string) byte { return s[0] },
+			func(s string) string { return s[1:] })
+		runDryIteration(q)
+		if got := q.Results(); !reflect.DeepEqual(got, want) {
+			t.Errorf("MaxElements=%d: GroupByT()=%v expected %v", maxElements, got, want)
+		}
+		assertSpillDone(t, s)
+	}
+}
+
+func TestSpill_GroupByT_PanicWhenKeySelectorFnIsInvalid(t *testing.T) {
+	s, _ := newTestSpill(t, 1)
+	mustPanicWithError(t, "GroupByT: parameter [keySelectorFn] has a invalid function signature. Expected: 'func(T)T', actual: 'func(int,int)bool'", func() {
+		s.GroupByT(From([]int{1}), func(i, j int) bool { return true }, func(i int) int { return i })
+	})
+}
+
+func TestSpill_Join(t *testing.T) {
+	outer := []int{0, 1, 2, 3, 4, 5, 8}
+	inner := []int{1, 2, 1, 4, 7, 6, 7, 2}
+	want := []any{
+		KeyValue{0, 2}, KeyValue{0, 4}, KeyValue{0, 6}, KeyValue{0, 2},
+		KeyValue{2, 2}, KeyValue{2, 4}, KeyValue{2, 6}, KeyValue{2, 2},
+		KeyValue{4, 2}, KeyValue{4, 4}, KeyValue{4, 6}, KeyValue{4, 2},
+		KeyValue{8, 2}, KeyValue{8, 4}, KeyValue{8, 6}, KeyValue{8, 2},
+		KeyValue{1, 1}, KeyValue{1, 1}, KeyValue{1, 7}, KeyValue{1, 7},
+		KeyValue{3, 1}, KeyValue{3, 1}, KeyValue{3, 7}, KeyValue{3, 7},
+		KeyValue{5, 1}, KeyValue{5, 1}, KeyValue{5, 7}, KeyValue{5, 7},
+	}
+
+	for _, maxElements := range []int{2, 3, 100} {
+		s, _ := newTestSpill(t, maxElements)
+
+		q := s.JoinT(From(outer), From(inner),
+			func(i int) int { return i % 2 },
+			func(i int) int { return i % 2 },
+			func(outer int, inner int) KeyValue { return KeyValue{outer, inner} })
+		if !testQueryIteration(q, want) {
+			t.Errorf("MaxElements=%d: JoinT()=%v expected %v", maxElements, toSlice(q), want)
+		}
+		assertSpillDone(t, s)
+	}
+}
+
+func TestSpill_JoinUnmatchedKeys(t *testing.T) {
+	s, _ := newTestSpill(t, 2)
+
+	q := s.Join(From([]int{1, 2, 3, 4}), From([]int{6, 3, 9, 1, 3}), identity, identity,
+		func(outer, inner any) any { return outer })
+	if want := []any{1, 3, 3}; !testQueryIteration(q, want) {
+		t.Errorf("Join()=%v expected %v", toSlice(q), want)
+	}
+	assertSpillDone(t, s)
+}
+
+func TestSpill_JoinT_PanicWhenResultSelectorFnIsInvalid(t *testing.T) {
+	s, _ := newTestSpill(t, 1)
+	mustPanicWithError(t, "JoinT: parameter [resultSelectorFn] has a invalid function signature. Expected: 'func(T,T)T', actual: 'func(int,int,int)int'", func() {
+		s.JoinT(From([]int{0}), From([]int{1}),
+			func(i int) int { return i },
+			func(i int) int { return i },
+			func(i, j, k int) int { return i })
+	})
+}
+
+func TestSpill_Err(t *testing.T) {
+	s, _ := newTestSpill(t, 2)
+
+	// foo is not registered with gob, so it cannot be spilled.
+	input := []any{foo{f1: 3}, foo{f1: 1}, foo{f1: 2}}
+	q := s.OrderBy(From(input), func(i any) any { return i.(foo).f1 })
+	if got := q.Results(); got != nil {
+		t.Errorf("OrderBy()=%v expected nil", got)
+	}
+	if s.Err() == nil {
+		t.Errorf("Err()=nil expected an error")
+	}
+
+	// The Spill doesn't run queries once it failed.
+	if got := s.Reverse(Range(1, 1)).Results(); got != nil {
+		t.Errorf("Reverse()=%v expected nil", got)
+	}
+}
+
+func TestSpill_JoinErrRemovesOuterRuns(t *testing.T) {
+	s, _ := newTestSpill(t, 2)
+
+	// The outer runs are spilled, then the inner ones fail since foo is not
+	// registered with gob.
+	inner := []any{foo{f1: 3}, foo{f1: 1}, foo{f1: 2}}
+	q := s.Join(From([]int{3, 1, 2, 1}), From(inner), identity,
+		func(i any) any { return i.(foo).f1 },
+		func(outer, inner any) any { return outer })
+	if got := q.Results(); got != nil {
+		t.Errorf("Join()=%v expected nil", got)
+	}
+	if s.Err() == nil {
+		t.Errorf("Err()=nil expected an error")
+	}
+	if entries, _ := os.ReadDir(s.dir); len(entries) != 0 {
+		t.Errorf("spill directory has %d files after a failed Join, expected 0", len(entries))
+	}
+}
@@ -0,0 +1,376 @@
+package linq
+
+import (
+	"math"
+	"slices"
+)
+
+// PercentileMethod specifies how Percentile interpolates when the requested
+// percentile lies between two elements of the sorted collection.
+type PercentileMethod int
+
+const (
+	// PercentileLinear interpolates linearly between the two closest
+	// elements. This is the default method.
+	PercentileLinear PercentileMethod = iota
+	// PercentileLower returns the lower of the two closest elements.
+	PercentileLower
+	// PercentileHigher returns the higher of the two closest elements.
+	PercentileHigher
+	// PercentileNearest returns the closest element, or the one with an even
+	// index if both are equally close.
+	PercentileNearest
+	// PercentileMidpoint returns the average of the two closest elements.
+	PercentileMidpoint
+)
+
+// Variance computes the sample variance of a collection of numeric values,
+// dividing by n-1, in a single pass with Welford's algorithm.
+//
+// Values can be of any integer, unsigned integer or float type. It panics if
+// the sequence contains non-numeric types. It returns math.NaN() if the
+// sequence contains less than two elements.
+func (q Query) Variance() float64 {
+	return q.VarianceBy(identity)
+}
+
+// VarianceBy computes the sample variance of the numeric values obtained by
+// invoking a transform function on each element of a collection.
+func (q Query) VarianceBy(selector func(any) any) float64 {
+	n, _, m2 := q.welford(selector)
+	if n < 2 {
+		return math.NaN()
+	}
+
+	return m2 / float64(n-1)
+}
+
+// VarianceByT is the typed version of VarianceBy.
+//
+//   - selectorFn is of type "func(TSource) TNumber"
+//
+// NOTE: VarianceBy has better performance than VarianceByT.
+func (q Query) VarianceByT(selectorFn any) float64 {
+	selectorGenericFunc, err := newGenericFunc(
+		"VarianceByT", "selectorFn", selectorFn,
+		simpleParamValidator(newElemTypeSlice(new(genericType)), newElemTypeSlice(new(genericType))),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	selectorFunc := func(item any) any {
+		return selectorGenericFunc.Call(item)
+	}
+
+	return q.VarianceBy(selectorFunc)
+}
+
+// PopulationVariance computes the population variance of a collection of
+// numeric values, dividing by n, in a single pass with Welford's algorithm.
+//
+// Values can be of any integer, unsigned integer or float type. It panics if
+// the sequence contains non-numeric types. It returns math.NaN() if the
+// sequence is empty.
+func (q Query) PopulationVariance() float64 {
+	return q.PopulationVarianceBy(identity)
+}
+
+// PopulationVarianceBy computes the population variance of the numeric values
+// obtained by invoking a transform function on each element of a collection.
+func (q Query) PopulationVarianceBy(selector func(any) any) float64 {
+	n, _, m2 := q.welford(selector)
+	if n == 0 {
+		return math.NaN()
+	}
+
+	return m2 / float64(n)
+}
+
+// PopulationVarianceByT is the typed version of PopulationVarianceBy.
+//
+//   - selectorFn is of type "func(TSource) TNumber"
+//
+// NOTE: PopulationVarianceBy has better performance than
+// PopulationVarianceByT.
+func (q Query) PopulationVarianceByT(selectorFn any) float64 {
+	selectorGenericFunc, err := newGenericFunc(
+		"PopulationVarianceByT", "selectorFn", selectorFn,
+		simpleParamValidator(newElemTypeSlice(new(genericType)), newElemTypeSlice(new(genericType))),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	selectorFunc := func(item any) any {
+		return selectorGenericFunc.Call(item)
+	}
+
+	return q.PopulationVarianceBy(selectorFunc)
+}
+
+// StdDev computes the sample standard deviation of a collection of numeric
+// values, which is the square root of Variance.
+func (q Query) StdDev() float64 {
+	return math.Sqrt(q.Variance())
+}
+
+// StdDevBy computes the sample standard deviation of the numeric values
+// obtained by invoking a transform function on each element of a collection.
+func (q Query) StdDevBy(selector func(any) any) float64 {
+	return math.Sqrt(q.VarianceBy(selector))
+}
+
+// StdDevByT is the typed version of StdDevBy.
+//
+//   - selectorFn is of type "func(TSource) TNumber"
+//
+// NOTE: StdDevBy has better performance than StdDevByT.
+func (q Query) StdDevByT(selectorFn any) float64 {
+	selectorGenericFunc, err := newGenericFunc(
+		"StdDevByT", "selectorFn", selectorFn,
+		simpleParamValidator(newElemTypeSlice(new(genericType)), newElemTypeSlice(new(genericType))),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	selectorFunc := func(item any) any {
+		return selectorGenericFunc.Call(item)
+	}
+
+	return q.StdDevBy(selectorFunc)
+}
+
+// PopulationStdDev computes the population standard deviation of a collection
+// of numeric values, which is the square root of PopulationVariance.
+func (q Query) PopulationStdDev() float64 {
+	return math.Sqrt(q.PopulationVariance())
+}
+
+// PopulationStdDevBy computes the population standard deviation of the
+// numeric values obtained by invoking a transform function on each element of
+// a collection.
+func (q Query) PopulationStdDevBy(selector func(any) any) float64 {
+	return math.Sqrt(q.PopulationVarianceBy(selector))
+}
+
+// PopulationStdDevByT is the typed version of PopulationStdDevBy.
+//
+//   - selectorFn is of type "func(TSource) TNumber"
+//
+// NOTE: PopulationStdDevBy has better performance than PopulationStdDevByT.
+func (q Query) PopulationStdDevByT(selectorFn any) float64 {
+	selectorGenericFunc, err := newGenericFunc(
+		"PopulationStdDevByT", "selectorFn", selectorFn,
+		simpleParamValidator(newElemTypeSlice(new(genericType)), newElemTypeSlice(new(genericType))),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	selectorFunc := func(item any) any {
+		return selectorGenericFunc.Call(item)
+	}
+
+	return q.PopulationStdDevBy(selectorFunc)
+}
+
+// Median computes the median of a collection of numeric values. For an even
+// number of elements, it returns the average of the two middle elements.
+//
+// Values can be of any integer, unsigned integer or float type. It panics if
+// the sequence contains non-numeric types. It returns math.NaN() if the
+// sequence is empty.
+func (q Query) Median() float64 {
+	return q.Percentile(50, PercentileLinear)
+}
+
+// MedianBy computes the median of the numeric values obtained by invoking a
+// transform function on each element of a collection.
+func (q Query) MedianBy(selector func(any) any) float64 {
+	return q.PercentileBy(50, PercentileLinear, selector)
+}
+
+// MedianByT is the typed version of MedianBy.
+//
+//   - selectorFn is of type "func(TSource) TNumber"
+//
+// NOTE: MedianBy has better performance than MedianByT.
+func (q Query) MedianByT(selectorFn any) float64 {
+	selectorGenericFunc, err := newGenericFunc(
+		"MedianByT", "selectorFn", selectorFn,
+		simpleParamValidator(newElemTypeSlice(new(genericType)), newElemTypeSlice(new(genericType))),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	selectorFunc := func(item any) any {
+		return selectorGenericFunc.Call(item)
+	}
+
+	return q.MedianBy(selectorFunc)
+}
+
+// Percentile computes the p-th percentile of a collection of numeric values,
+// where p is between 0 and 100. When the percentile lies between two elements
+// of the sorted collection, the result is computed according to method.
+//
+// Values can be of any integer, unsigned integer or float type. It panics if
+// the sequence contains non-numeric types or if p is out of range. It returns
+// math.NaN() if the sequence is empty.
+func (q Query) Percentile(p float64, method PercentileMethod) float64 {
+	return q.PercentileBy(p, method, identity)
+}
+
+// PercentileBy computes the p-th percentile of the numeric values obtained by
+// invoking a transform function on each element of a collection.
+func (q Query) PercentileBy(p float64, method PercentileMethod,
+	selector func(any) any) float64 {
+	if !(p >= 0 && p <= 100) {
+		panic("Percentile: p must be between 0 and 100")
+	}
+
+	values := q.floats(selector)
+	if len(values) == 0 {
+		return math.NaN()
+	}
+
+	slices.Sort(values)
+
+	rank := float64(len(values)-1) * p / 100
+	lower, upper := math.Floor(rank), math.Ceil(rank)
+	x, y := values[int(lower)], values[int(upper)]
+
+	switch method {
+	case PercentileLower:
+		return x
+	case PercentileHigher:
+		return y
+	case PercentileNearest:
+		return values[int(math.RoundToEven(rank))]
+	case PercentileMidpoint:
+		return (x + y) / 2
+	default:
+		return x + (rank-lower)*(y-x)
+	}
+}
+
+// PercentileByT is the typed version of PercentileBy.
+//
+//   - selectorFn is of type "func(TSource) TNumber"
+//
+// NOTE: PercentileBy has better performance than PercentileByT.
+func (q Query) PercentileByT(p float64, method PercentileMethod,
+	selectorFn any) float64 {
+	selectorGenericFunc, err := newGenericFunc(
+		"PercentileByT", "selectorFn", selectorFn,
+		simpleParamValidator(newElemTypeSlice(new(genericType)), newElemTypeSlice(new(genericType))),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	selectorFunc := func(item any) any {
+		return selectorGenericFunc.Call(item)
+	}
+
+	return q.PercentileBy(p, method, selectorFunc)
+}
+
+// Mode returns the most frequent element of a collection. If several elements
+// are equally frequent, the one that appears first is returned. It returns nil
+// if the collection is empty.
+//
+// Unlike the other statistical aggregates, Mode works with elements of any
+// comparable type.
+func (q Query) Mode() any {
+	return q.ModeBy(identity)
+}
+
+// ModeBy returns the most frequent value obtained by invoking a transform
+// function on each element of a collection. If several values are equally
+// frequent, the one that appears first is returned.
+func (q Query) ModeBy(selector func(any) any) any {
+	counts := make(map[any]int)
+	var keys []any
+	for item := range q.Iterate {
+		key := selector(item)
+		if _, seen := counts[key]; !seen {
+			keys = append(keys, key)
+		}
+		counts[key]++
+	}
+
+	var r any
+	best := 0
+	for _, key := range keys {
+		if counts[key] > best {
+			r, best = key, counts[key]
+		}
+	}
+
+	return r
+}
+
+// ModeByT is the typed version of ModeBy.
+//
+//   - selectorFn is of type "func(TSource) TKey"
+//
+// NOTE: ModeBy has better performance than ModeByT.
+func (q Query) ModeByT(selectorFn any) any {
+	selectorGenericFunc, err := newGenericFunc(
+		"ModeByT", "selectorFn", selectorFn,
+		simpleParamValidator(newElemTypeSlice(new(genericType)), newElemTypeSlice(new(genericType))),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	selectorFunc := func(item any) any {
+		return selectorGenericFunc.Call(item)
+	}
+
+	return q.ModeBy(selectorFunc)
+}
+
+// welford computes the number of elements, the mean and the sum of squared
+// differences from the mean of the numeric values obtained by invoking
+// selector on each element of the collection.
+func (q Query) welford(selector func(any) any) (n int, mean, m2 float64) {
+	var conv floatConverter
+	for item := range q.Iterate {
+		value := selector(item)
+		if conv == nil {
+			conv = getNumberConverter(value)
+		}
+
+		x := conv(value)
+		n++
+		delta := x - mean
+		mean += delta / float64(n)
+		m2 += delta * (x - mean)
+	}
+
+	return
+}
+
+// floats collects the numeric values obtained by invoking selector on each
+// element of the collection as float64.
+func (q Query) floats(selector func(any) any) (r []float64) {
+	var conv floatConverter
+	for item := range q.Iterate {
+		value := selector(item)
+		if conv == nil {
+			conv = getNumberConverter(value)
+		}
+		r = append(r, conv(value))
+	}
+
+	return
+}
+
+func identity(item any) any {
+	return item
+}
@@ -0,0 +1,196 @@
+package linq
+
+import (
+	"math"
+	"testing"
+)
+
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) <= 1e-9*math.Max(1, math.Max(math.Abs(a), math.Abs(b)))
+}
+
+func TestVariance(t *testing.T) {
+	tests := []struct {
+		input      any
+		sample     float64
+		population float64
+	}{
+		{[]int{2, 4, 4, 4, 5, 5, 7, 9}, 32.0 / 7, 4},
+		{[]uint8{1, 2, 3, 4}, 5.0 / 3, 1.25},
+		{[]float32{1.5, 2.5}, 0.5, 0.25},
+		{[]float64{1e9 + 4, 1e9 + 7, 1e9 + 13, 1e9 + 16}, 30, 22.5},
+	}
+
+	for _, test := range tests {
+		if r := From(test.input).Variance(); !almostEqual(r, test.sample) {
+			t.Errorf("From(%v).Variance()=%v expected %v", test.input, r, test.sample)
+		}
+		if r := From(test.input).PopulationVariance(); !almostEqual(r, test.population) {
+			t.Errorf("From(%v).PopulationVariance()=%v expected %v", test.input, r, test.population)
+		}
+		if r := From(test.input).StdDev(); !almostEqual(r, math.Sqrt(test.sample)) {
+			t.Errorf("From(%v).StdDev()=%v expected %v", test.input, r, math.Sqrt(test.sample))
+		}
+		if r := From(test.input).PopulationStdDev(); !almostEqual(r, math.Sqrt(test.population)) {
+			t.Errorf("From(%v).PopulationStdDev()=%v expected %v", test.input, r, math.Sqrt(test.population))
+		}
+	}
+}
+
+func TestVariance_NotEnoughElements(t *testing.T) {
+	if r := From([]int{}).PopulationVariance(); !math.IsNaN(r) {
+		t.Errorf("From([]).PopulationVariance()=%v expected NaN", r)
+	}
+	if r := From([]int{1}).Variance(); !math.IsNaN(r) {
+		t.Errorf("From([1]).Variance()=%v expected NaN", r)
+	}
+	if r := From([]int{1}).PopulationVariance(); r != 0 {
+		t.Errorf("From([1]).PopulationVariance()=%v expected 0", r)
+	}
+}
+
+func TestVarianceBy(t *testing.T) {
+	input := []foo{{f1: 1}, {f1: 2}, {f1: 3}, {f1: 4}}
+	selector := func(i any) any { return i.(foo).f1 }
+
+	if r := From(input).VarianceBy(selector); !almostEqual(r, 5.0/3) {
+		t.Errorf("From(%v).VarianceBy()=%v expected %v", input, r, 5.0/3)
+	}
+	if r := From(input).PopulationStdDevBy(selector); !almostEqual(r, math.Sqrt(1.25)) {
+		t.Errorf("From(%v).PopulationStdDevBy()=%v expected %v", input, r, math.Sqrt(1.25))
+	}
+	if r := From(input).VarianceByT(func(f foo) int { return f.f1 }); !almostEqual(r, 5.0/3) {
+		t.Errorf("From(%v).VarianceByT()=%v expected %v", input, r, 5.0/3)
+	}
+}
+
+func TestVarianceByT_PanicWhenSelectorFnIsInvalid(t *testing.T) {
+	mustPanicWithError(t, "VarianceByT: parameter [selectorFn] has a invalid function signature. Expected: 'func(T)T', actual: 'func(int,int)int'", func() {
+		From([]int{1, 2}).VarianceByT(func(i, j int) int { return i })
+	})
+}
+
+func TestPopulationVarianceByT_PanicWhenSelectorFnIsInvalid(t *testing.T) {
+	mustPanicWithError(t, "PopulationVarianceByT: parameter [selectorFn] has a invalid function signature. Expected: 'func(T)T', actual: 'func(int,int)int'", func() {
+		From([]int{1, 2}).PopulationVarianceByT(func(i, j int) int { return i })
+	})
+}
+
+func TestStdDevByT_PanicWhenSelectorFnIsInvalid(t *testing.T) {
+	mustPanicWithError(t, "StdDevByT: parameter [selectorFn] has a invalid function signature. Expected: 'func(T)T', actual: 'func(int,int)int'", func() {
+		From([]int{1, 2}).StdDevByT(func(i, j int) int { return i })
+	})
+}
+
+func TestPopulationStdDevByT_PanicWhenSelectorFnIsInvalid(t *testing.T) {
+	mustPanicWithError(t, "PopulationStdDevByT: parameter [selectorFn] has a invalid function signature. Expected: 'func(T)T', actual: 'func(int,int)int'", func() {
+		From([]int{1, 2}).PopulationStdDevByT(func(i, j int) int { return i })
+	})
+}
+
+func TestMedian(t *testing.T) {
+	tests := []struct {
+		input any
+		want  float64
+	}{
+		{[]int{3, 1, 2}, 2},
+		{[]int{4, 1, 3, 2}, 2.5},
+		{[]uint{7}, 7},
+		{[]float64{-1.5, 10, 0.5}, 0.5},
+	}
+
+	for _, test := range tests {
+		if r := From(test.input).Median(); r != test.want {
+			t.Errorf("From(%v).Median()=%v expected %v", test.input, r, test.want)
+		}
+	}
+
+	if r := From([]int{}).Median(); !math.IsNaN(r) {
+		t.Errorf("From([]).Median()=%v expected NaN", r)
+	}
+}
+
+func TestMedianBy(t *testing.T) {
+	input := []foo{{f1: 10}, {f1: 30}, {f1: 20}, {f1: 40}}
+
+	if r := From(input).MedianBy(func(i any) any { return i.(foo).f1 }); r != 25 {
+		t.Errorf("From(%v).MedianBy()=%v expected 25", input, r)
+	}
+}
+
+func TestMedianByT_PanicWhenSelectorFnIsInvalid(t *testing.T) {
+	mustPanicWithError(t, "MedianByT: parameter [selectorFn] has a invalid function signature. Expected: 'func(T)T', actual: 'func(int,int)int'", func() {
+		From([]int{1, 2}).MedianByT(func(i, j int) int { return i })
+	})
+}
+
+func TestPercentile(t *testing.T) {
+	input := []int{15, 20, 35, 40, 50}
+
+	tests := []struct {
+		p      float64
+		method PercentileMethod
+		want   float64
+	}{
+		{0, PercentileLinear, 15},
+		{100, PercentileLinear, 50},
+		{40, PercentileLinear, 29},
+		{40, PercentileLower, 20},
+		{40, PercentileHigher, 35},
+		{40, PercentileNearest, 35},
+		{40, PercentileMidpoint, 27.5},
+		{37.5, PercentileNearest, 35},
+		{12.5, PercentileNearest, 15},
+	}
+
+	for _, test := range tests {
+		if r := From(input).Percentile(test.p, test.method); !almostEqual(r, test.want) {
+			t.Errorf("From(%v).Percentile(%v, %v)=%v expected %v", input, test.p, test.method, r, test.want)
+		}
+	}
+}
+
+func TestPercentile_PanicWhenPIsOutOfRange(t *testing.T) {
+	for _, p := range []float64{-1, 101, math.NaN()} {
+		mustPanicWithError(t, "Percentile: p must be between 0 and 100", func() {
+			From([]int{1, 2}).Percentile(p, PercentileLinear)
+		})
+	}
+}
+
+func TestPercentileByT_PanicWhenSelectorFnIsInvalid(t *testing.T) {
+	mustPanicWithError(t, "PercentileByT: parameter [selectorFn] has a invalid function signature. Expected: 'func(T)T', actual: 'func(int,int)int'", func() {
+		From([]int{1, 2}).PercentileByT(90, PercentileLinear, func(i, j int) int { return i })
+	})
+}
+
+func TestMode(t *testing.T) {
+	tests := []struct {
+		input any
+		want  any
+	}{
+		{[]int{1, 2, 2, 3, 3}, 2},
+		{[]string{"b", "a", "a", "c", "b"}, "b"},
+		{[]int{}, nil},
+	}
+
+	for _, test := range tests {
+		if r := From(test.input).Mode(); r != test.want {
+			t.Errorf("From(%v).Mode()=%v expected %v", test.input, r, test.want)
+		}
+	}
+}
+
+func TestModeBy(t *testing.T) {
+	input := []string{"apple", "kiwi", "pear", "plum", "banana"}
+
+	if r := From(input).ModeBy(func(i any) any { return len(i.(string)) }); r != 4 {
+		t.Errorf("From(%v).ModeBy()=%v expected 4", input, r)
+	}
+}
+
+func TestModeByT_PanicWhenSelectorFnIsInvalid(t *testing.T) {
+	mustPanicWithError(t, "ModeByT: parameter [selectorFn] has a invalid function signature. Expected: 'func(T)T', actual: 'func(int,int)int'", func() {
+		From([]int{1, 2}).ModeByT(func(i, j int) int { return i })
+	})
+}
@@ -0,0 +1,211 @@
+package linq
+
+import (
+	"container/heap"
+	"sort"
+)
+
+// TopK returns the count elements of a collection with the largest keys, as
+// returned by a specified key selector function, in descending order of their
+// keys. Elements with equal keys keep their relative order from the source.
+//
+// TopK is equivalent to OrderByDescending followed by Take, but it keeps only
+// count elements in memory in a bounded heap instead of sorting the whole
+// collection, which takes O(n log count) time.
+func (q Query) TopK(count int, keySelector func(any) any) Query {
+	return q.partialSortQuery([]order{{selector: keySelector, desc: true}}, count)
+}
+
+// TopKT is the typed version of TopK.
+//
+//   - keySelectorFn is of type "func(TSource) TKey"
+//
+// NOTE: TopK has better performance than TopKT.
+func (q Query) TopKT(count int, keySelectorFn any) Query {
+	keySelectorGenericFunc, err := newGenericFunc(
+		"TopKT", "keySelectorFn", keySelectorFn,
+		simpleParamValidator(newElemTypeSlice(new(genericType)), newElemTypeSlice(new(genericType))),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	keySelectorFunc := func(item any) any {
+		return keySelectorGenericFunc.Call(item)
+	}
+
+	return q.TopK(count, keySelectorFunc)
+}
+
+// BottomK returns the count elements of a collection with the smallest keys,
+// as returned by a specified key selector function, in ascending order of
+// their keys. Elements with equal keys keep their relative order from the
+// source.
+//
+// BottomK is equivalent to OrderBy followed by Take, but it keeps only count
+// elements in memory in a bounded heap instead of sorting the whole
+// collection, which takes O(n log count) time.
+func (q Query) BottomK(count int, keySelector func(any) any) Query {
+	return q.partialSortQuery([]order{{selector: keySelector}}, count)
+}
+
+// BottomKT is the typed version of BottomK.
+//
+//   - keySelectorFn is of type "func(TSource) TKey"
+//
+// NOTE: BottomK has better performance than BottomKT.
+func (q Query) BottomKT(count int, keySelectorFn any) Query {
+	keySelectorGenericFunc, err := newGenericFunc(
+		"BottomKT", "keySelectorFn", keySelectorFn,
+		simpleParamValidator(newElemTypeSlice(new(genericType)), newElemTypeSlice(new(genericType))),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	keySelectorFunc := func(item any) any {
+		return keySelectorGenericFunc.Call(item)
+	}
+
+	return q.BottomK(count, keySelectorFunc)
+}
+
+// Take returns a specified number of contiguous elements from the start of a
+// sorted collection.
+//
+// Unlike Take on Query, it doesn't sort the whole collection: only the first
+// count elements are kept in a bounded heap while the source is iterated,
+// which takes O(n log count) time. Elements with equal keys keep their
+// relative order from the source.
+func (oq OrderedQuery) Take(count int) Query {
+	if oq.original.Iterate == nil {
+		// The ordered query is not backed by a sort, e.g. it was returned by
+		// Distinct.
+		return oq.Query.Take(count)
+	}
+	if oq.spill != nil && count > oq.spill.maxElements {
+		// The heap would exceed the memory budget of the Spill.
+		return oq.Query.Take(count)
+	}
+
+	return oq.original.partialSortQuery(oq.orders, count)
+}
+
+func (q Query) partialSortQuery(orders []order, count int) Query {
+	return Query{
+		Iterate: func(yield func(any) bool) {
+			for _, item := range q.partialSort(orders, count) {
+				if !yield(item) {
+					return
+				}
+			}
+		},
+	}
+}
+
+// rankedItem is an element of a collection along with its sort keys and its
+// position in the source, which breaks the ties between equal keys.
+type rankedItem struct {
+	item  any
+	keys  []any
+	index int
+}
+
+// rankedHeap is a heap of rankedItem values that implements heap.Interface.
+// The root of the heap is the element that is sorted last, so that it can be
+// evicted when a better element is found.
+type rankedHeap struct {
+	items  []rankedItem
+	orders []order
+}
+
+// before reports whether a is sorted before b.
+func (h *rankedHeap) before(a, b rankedItem) bool {
+	for i, o := range h.orders {
+		switch c := o.compare(a.keys[i], b.keys[i]); {
+		case c < 0:
+			return !o.desc
+		case c > 0:
+			return o.desc
+		}
+	}
+
+	return a.index < b.index
+}
+
+// rankedSorter implements sort.Interface over the items of a rankedHeap in
+// sort order, rather than in the reverse order of the heap.
+type rankedSorter struct {
+	*rankedHeap
+}
+
+func (h *rankedHeap) sorter() rankedSorter {
+	return rankedSorter{h}
+}
+
+func (s rankedSorter) Less(i, j int) bool {
+	return s.before(s.items[i], s.items[j])
+}
+
+func (h *rankedHeap) Len() int {
+	return len(h.items)
+}
+
+func (h *rankedHeap) Less(i, j int) bool {
+	return h.before(h.items[j], h.items[i])
+}
+
+func (h *rankedHeap) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+}
+
+func (h *rankedHeap) Push(x any) {
+	h.items = append(h.items, x.(rankedItem))
+}
+
+func (h *rankedHeap) Pop() any {
+	x := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	return x
+}
+
+// partialSort returns the first count elements of the collection sorted by
+// orders, keeping at most count elements in memory.
+func (q Query) partialSort(orders []order, count int) []any {
+	if count <= 0 {
+		return nil
+	}
+
+	h := &rankedHeap{orders: make([]order, len(orders))}
+	copy(h.orders, orders)
+
+	index := 0
+	for item := range q.Iterate {
+		candidate := rankedItem{item: item, keys: make([]any, len(orders)), index: index}
+		for i, o := range orders {
+			candidate.keys[i] = o.selector(item)
+		}
+
+		if index == 0 {
+			for i := range h.orders {
+				h.orders[i].compare = getComparer(candidate.keys[i])
+			}
+		}
+		index++
+
+		if h.Len() < count {
+			heap.Push(h, candidate)
+		} else if h.before(candidate, h.items[0]) {
+			h.items[0] = candidate
+			heap.Fix(h, 0)
+		}
+	}
+
+	sort.Sort(h.sorter())
+
+	r := make([]any, len(h.items))
+	for i, ranked := range h.items {
+		r[i] = ranked.item
+	}
+	return r
+}
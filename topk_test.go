@@ -0,0 +1,89 @@
+package linq
+
+import "testing"
+
+func TestTopK(t *testing.T) {
+	input := []foo{{f1: 3, f3: "a"}, {f1: 9, f3: "b"}, {f1: 5, f3: "c"}, {f1: 9, f3: "d"}, {f1: 1, f3: "e"}, {f1: 5, f3: "f"}}
+	key := func(i any) any { return i.(foo).f1 }
+
+	tests := []struct {
+		count  int
+		output []any
+	}{
+		{0, nil},
+		{1, []any{input[1]}},
+		{3, []any{input[1], input[3], input[2]}},
+		{4, []any{input[1], input[3], input[2], input[5]}},
+		{10, []any{input[1], input[3], input[2], input[5], input[0], input[4]}},
+	}
+
+	for _, test := range tests {
+		if q := From(input).TopK(test.count, key); !testQueryIteration(q, test.output) {
+			t.Errorf("From(%v).TopK(%d)=%v expected %v", input, test.count, toSlice(q), test.output)
+		}
+	}
+}
+
+func TestTopKT_PanicWhenKeySelectorFnIsInvalid(t *testing.T) {
+	mustPanicWithError(t, "TopKT: parameter [keySelectorFn] has a invalid function signature. Expected: 'func(T)T', actual: 'func(int,int)int'", func() {
+		From([]int{1, 2}).TopKT(1, func(i, j int) int { return i })
+	})
+}
+
+func TestBottomK(t *testing.T) {
+	input := []string{"pear", "fig", "apple", "kiwi", "banana", "yam"}
+	want := []any{"fig", "yam", "pear"}
+
+	if q := From(input).BottomKT(3, func(s string) int { return len(s) }); !testQueryIteration(q, want) {
+		t.Errorf("From(%v).BottomKT(3)=%v expected %v", input, toSlice(q), want)
+	}
+}
+
+func TestBottomKT_PanicWhenKeySelectorFnIsInvalid(t *testing.T) {
+	mustPanicWithError(t, "BottomKT: parameter [keySelectorFn] has a invalid function signature. Expected: 'func(T)T', actual: 'func(int,int)int'", func() {
+		From([]int{1, 2}).BottomKT(1, func(i, j int) int { return i })
+	})
+}
+
+func TestOrderedQuery_Take(t *testing.T) {
+	input := Range(1, 100).Select(func(i any) any { return (i.(int) * 37) % 101 }).Results()
+
+	ordered := From(input).
+		OrderBy(func(i any) any { return i.(int) % 10 }).
+		ThenByDescending(func(i any) any { return i })
+
+	want := toSlice(ordered.Query)[:7]
+	if q := ordered.Take(7); !testQueryIteration(q, want) {
+		t.Errorf("OrderBy().ThenByDescending().Take(7)=%v expected %v", toSlice(q), want)
+	}
+
+	if q := ordered.Take(0); !testQueryIteration(q, nil) {
+		t.Errorf("OrderBy().Take(0)=%v expected []", toSlice(q))
+	}
+}
+
+func TestOrderedQuery_TakeWithTies(t *testing.T) {
+	ordered := Range(0, 200).OrderBy(func(i any) any { return i.(int) % 3 })
+
+	for _, count := range []int{1, 10, 67, 150} {
+		want := ordered.Results()[:count]
+		if q := ordered.Take(count); !testQueryIteration(q, want) {
+			t.Errorf("OrderBy().Take(%d)=%v expected %v", count, toSlice(q), want)
+		}
+	}
+
+	descending := Range(0, 200).OrderByDescending(func(i any) any { return i.(int) % 3 })
+	want := descending.Results()[:10]
+	if q := descending.Take(10); !testQueryIteration(q, want) {
+		t.Errorf("OrderByDescending().Take(10)=%v expected %v", toSlice(q), want)
+	}
+}
+
+func TestOrderedQuery_TakeAfterDistinct(t *testing.T) {
+	input := []int{3, 1, 2, 3, 1}
+	want := []any{1, 2}
+
+	if q := From(input).OrderBy(func(i any) any { return i }).Distinct().Take(2); !testQueryIteration(q, want) {
+		t.Errorf("From(%v).OrderBy().Distinct().Take(2)=%v expected %v", input, toSlice(q), want)
+	}
+}
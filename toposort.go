@@ -0,0 +1,184 @@
+package linq
+
+import (
+	"container/heap"
+	"fmt"
+)
+
+// CycleError is the error returned by TopologicalSort when the dependencies
+// of the elements form a cycle.
+type CycleError struct {
+	// Elements holds the elements that form the cycle, each one depending on
+	// the next one and the last one depending on the first one.
+	Elements []any
+}
+
+func (e *CycleError) Error() string {
+	return fmt.Sprintf("TopologicalSort: dependency cycle between elements %v", e.Elements)
+}
+
+// TopologicalSort sorts the elements of a collection so that every element
+// comes after the elements it depends on.
+//
+// keySelector returns the key that identifies an element, and
+// dependenciesSelector returns the keys of the elements it depends on.
+// Dependencies on keys that don't match any element of the collection are
+// ignored. If several elements have the same key, depending on that key means
+// depending on all of them.
+//
+// The sort is stable: when several elements are ready to be placed, they are
+// placed in the order they have in the source collection, so a collection
+// without dependencies is returned unchanged.
+//
+// If the dependencies form a cycle, TopologicalSort returns a *CycleError that
+// lists the elements involved.
+func (q Query) TopologicalSort(keySelector func(any) any,
+	dependenciesSelector func(any) Query) ([]any, error) {
+	items := q.Results()
+
+	indexes := make(map[any][]int)
+	for i, item := range items {
+		key := keySelector(item)
+		indexes[key] = append(indexes[key], i)
+	}
+
+	// dependents[i] holds the indexes of the elements that depend on the i-th
+	// element, and pending[i] the number of dependencies of the i-th element
+	// that haven't been placed yet.
+	dependents := make([][]int, len(items))
+	dependencies := make([][]int, len(items))
+	pending := make([]int, len(items))
+	for i, item := range items {
+		for key := range dependenciesSelector(item).Iterate {
+			for _, j := range indexes[key] {
+				dependents[j] = append(dependents[j], i)
+				dependencies[i] = append(dependencies[i], j)
+				pending[i]++
+			}
+		}
+	}
+
+	ready := &intHeap{}
+	for i := range items {
+		if pending[i] == 0 {
+			ready.items = append(ready.items, i)
+		}
+	}
+
+	r := make([]any, 0, len(items))
+	for ready.Len() > 0 {
+		i := heap.Pop(ready).(int)
+		r = append(r, items[i])
+
+		for _, j := range dependents[i] {
+			pending[j]--
+			if pending[j] == 0 {
+				heap.Push(ready, j)
+			}
+		}
+	}
+
+	if len(r) < len(items) {
+		return nil, &CycleError{Elements: findCycle(items, dependencies, pending)}
+	}
+
+	return r, nil
+}
+
+// TopologicalSortT is the typed version of TopologicalSort.
+//
+//   - keySelectorFn is of type "func(TSource) TKey"
+//   - dependenciesSelectorFn is of type "func(TSource) Query"
+//
+// NOTE: TopologicalSort has better performance than TopologicalSortT.
+func (q Query) TopologicalSortT(keySelectorFn any,
+	dependenciesSelectorFn any) ([]any, error) {
+	keySelectorGenericFunc, err := newGenericFunc(
+		"TopologicalSortT", "keySelectorFn", keySelectorFn,
+		simpleParamValidator(newElemTypeSlice(new(genericType)), newElemTypeSlice(new(genericType))),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	keySelectorFunc := func(item any) any {
+		return keySelectorGenericFunc.Call(item)
+	}
+
+	dependenciesSelectorGenericFunc, err := newGenericFunc(
+		"TopologicalSortT", "dependenciesSelectorFn", dependenciesSelectorFn,
+		simpleParamValidator(newElemTypeSlice(new(genericType)), newElemTypeSlice(new(Query))),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	dependenciesSelectorFunc := func(item any) Query {
+		return dependenciesSelectorGenericFunc.Call(item).(Query)
+	}
+
+	return q.TopologicalSort(keySelectorFunc, dependenciesSelectorFunc)
+}
+
+// findCycle returns the elements of a dependency cycle among the elements that
+// couldn't be placed by TopologicalSort. Every such element has at least one
+// unplaced dependency, so following them from any of these elements
+// eventually revisits one.
+func findCycle(items []any, dependencies [][]int, pending []int) []any {
+	start := 0
+	for pending[start] == 0 {
+		start++
+	}
+
+	position := make(map[int]int)
+	var walk []int
+	for i := start; ; {
+		if p, seen := position[i]; seen {
+			walk = walk[p:]
+			break
+		}
+
+		position[i] = len(walk)
+		walk = append(walk, i)
+
+		for _, j := range dependencies[i] {
+			if pending[j] > 0 {
+				i = j
+				break
+			}
+		}
+	}
+
+	cycle := make([]any, len(walk))
+	for k, i := range walk {
+		cycle[k] = items[i]
+	}
+	return cycle
+}
+
+// intHeap is a min-heap of ints that implements heap.Interface.
+type intHeap struct {
+	items []int
+}
+
+func (h *intHeap) Len() int {
+	return len(h.items)
+}
+
+func (h *intHeap) Less(i, j int) bool {
+	return h.items[i] < h.items[j]
+}
+
+func (h *intHeap) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+}
+
+func (h *intHeap) Push(x any) {
+	h.items = append(h.items, x.(int))
+}
+
+func (h *intHeap) Pop() any {
+	x := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	return x
+}
@@ -0,0 +1,95 @@
+package linq
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type buildStep struct {
+	name string
+	deps []string
+}
+
+func buildStepName(i any) any {
+	return i.(buildStep).name
+}
+
+func buildStepDeps(i any) Query {
+	return FromSlice(i.(buildStep).deps)
+}
+
+func TestTopologicalSort(t *testing.T) {
+	tests := []struct {
+		input  []buildStep
+		output []any
+	}{
+		{nil, nil},
+		{[]buildStep{{name: "c"}, {name: "a"}, {name: "b"}}, []any{"c", "a", "b"}},
+		{[]buildStep{
+			{"link", []string{"compile", "assets"}},
+			{"compile", []string{"generate"}},
+			{"test", []string{"compile"}},
+			{"assets", nil},
+			{"generate", []string{"missing"}},
+		}, []any{"assets", "generate", "compile", "link", "test"}},
+		{[]buildStep{
+			{"d", []string{"b", "c"}},
+			{"c", []string{"a"}},
+			{"b", []string{"a"}},
+			{"a", nil},
+		}, []any{"a", "c", "b", "d"}},
+	}
+
+	for _, test := range tests {
+		r, err := FromSlice(test.input).TopologicalSort(buildStepName, buildStepDeps)
+		if err != nil {
+			t.Fatalf("From(%v).TopologicalSort() returned error %v", test.input, err)
+		}
+
+		if got := From(r).Select(buildStepName).Results(); !reflect.DeepEqual(got, test.output) {
+			t.Errorf("From(%v).TopologicalSort()=%v expected %v", test.input, got, test.output)
+		}
+	}
+}
+
+func TestTopologicalSort_Cycle(t *testing.T) {
+	tests := []struct {
+		input []buildStep
+		cycle []any
+	}{
+		{[]buildStep{
+			{"a", nil},
+			{"b", []string{"a", "d"}},
+			{"c", []string{"b"}},
+			{"d", []string{"c"}},
+			{"e", []string{"d"}},
+		}, []any{"b", "d", "c"}},
+		{[]buildStep{{"a", []string{"a"}}}, []any{"a"}},
+	}
+
+	for _, test := range tests {
+		r, err := FromSlice(test.input).TopologicalSort(buildStepName, buildStepDeps)
+
+		var cycleErr *CycleError
+		if !errors.As(err, &cycleErr) {
+			t.Fatalf("From(%v).TopologicalSort()=%v,%v expected a CycleError", test.input, r, err)
+		}
+
+		if got := From(cycleErr.Elements).Select(buildStepName).Results(); !reflect.DeepEqual(got, test.cycle) {
+			t.Errorf("From(%v).TopologicalSort() cycle=%v expected %v", test.input, got, test.cycle)
+		}
+	}
+}
+
+func TestTopologicalSortT_PanicWhenKeySelectorFnIsInvalid(t *testing.T) {
+	mustPanicWithError(t, "TopologicalSortT: parameter [keySelectorFn] has a invalid function signature. Expected: 'func(T)T', actual: 'func(int,int)int'", func() {
+		From([]int{1, 2}).TopologicalSortT(func(i, j int) int { return i }, func(i int) Query { return From([]int{}) })
+	})
+}
+
+func TestTopologicalSortT_PanicWhenDependenciesSelectorFnIsInvalid(t *testing.T) {
+	mustPanicWithError(t, "TopologicalSortT: parameter [dependenciesSelectorFn] has a invalid function signature. Expected: 'func(T)linq.Query', actual: 'func(int)int'", func() {
+		From([]int{1, 2}).TopologicalSortT(func(i int) int { return i }, func(i int) int { return i })
+	})
+}
@@ -0,0 +1,266 @@
+package linq
+
+// TraversalOrder specifies the order in which Traverse and TraverseNodes visit
+// the nodes of a tree or graph.
+type TraversalOrder int
+
+const (
+	// PreOrder visits a node before its children, depth-first.
+	PreOrder TraversalOrder = iota
+	// PostOrder visits a node after its children, depth-first.
+	PostOrder
+	// BreadthFirst visits all the nodes of a depth before the nodes of the
+	// next depth.
+	BreadthFirst
+)
+
+// TreeNode is a type used to store the nodes yielded by TraverseNodes.
+type TreeNode struct {
+	// Value is the node itself.
+	Value any
+	// Depth is the distance from the root, which has a depth of zero.
+	Depth int
+	// Path holds the nodes from the root down to Value, inclusive.
+	Path []any
+}
+
+// TraverseOptions configures TraverseNodes.
+type TraverseOptions struct {
+	// Order is the order in which the nodes are visited.
+	Order TraversalOrder
+	// MaxDepth, if positive, is the depth of the deepest nodes to visit. The
+	// children of the nodes at that depth are not requested.
+	MaxDepth int
+	// DetectCycles skips the nodes that have already been visited, so that
+	// every node is yielded at most once and traversing a cyclic graph
+	// terminates. Nodes have to be comparable unless KeySelector is set.
+	DetectCycles bool
+	// KeySelector, if set, returns the key that identifies a node for cycle
+	// detection.
+	KeySelector func(any) any
+}
+
+// Traverse generates the sequence of the nodes of a tree, starting from root.
+// The children of every node are obtained from childrenSelector, and nodes are
+// visited in the given order.
+//
+// Traverse is lazy: childrenSelector is invoked only when the traversal
+// reaches a node's children, and the traversal ends as soon as the consumer
+// stops it. Traverse doesn't detect cycles, use TraverseNodes to traverse
+// graphs.
+func Traverse(root any, childrenSelector func(any) Query,
+	order TraversalOrder) Query {
+	return Query{
+		Iterate: func(yield func(any) bool) {
+			t := traversal{
+				childrenSelector: childrenSelector,
+				options:          TraverseOptions{Order: order},
+				yield: func(node TreeNode) bool {
+					return yield(node.Value)
+				},
+			}
+			t.run(root)
+		},
+	}
+}
+
+// TraverseT is the typed version of Traverse.
+//
+//   - childrenSelectorFn is of type "func(TNode) Query"
+//
+// NOTE: Traverse has better performance than TraverseT.
+func TraverseT(root any, childrenSelectorFn any,
+	order TraversalOrder) Query {
+	childrenSelectorGenericFunc, err := newGenericFunc(
+		"TraverseT", "childrenSelectorFn", childrenSelectorFn,
+		simpleParamValidator(newElemTypeSlice(new(genericType)), newElemTypeSlice(new(Query))),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	childrenSelectorFunc := func(node any) Query {
+		return childrenSelectorGenericFunc.Call(node).(Query)
+	}
+
+	return Traverse(root, childrenSelectorFunc, order)
+}
+
+// TraverseNodes generates the sequence of the nodes of a tree or graph,
+// starting from root, as TreeNode values that carry the depth and path of
+// every node. The children of every node are obtained from childrenSelector.
+//
+// The traversal order, the maximum depth and cycle detection are configured
+// with options. Like Traverse, TraverseNodes is lazy and the traversal ends as
+// soon as the consumer stops it. Every yielded TreeNode has its own Path slice
+// that can be safely retained by the caller.
+func TraverseNodes(root any, childrenSelector func(any) Query,
+	options TraverseOptions) Query {
+	return Query{
+		Iterate: func(yield func(any) bool) {
+			t := traversal{
+				childrenSelector: childrenSelector,
+				options:          options,
+				withPath:         true,
+				yield: func(node TreeNode) bool {
+					return yield(node)
+				},
+			}
+			t.run(root)
+		},
+	}
+}
+
+// TraverseNodesT is the typed version of TraverseNodes.
+//
+//   - childrenSelectorFn is of type "func(TNode) Query"
+//
+// NOTE: TraverseNodes has better performance than TraverseNodesT.
+func TraverseNodesT(root any, childrenSelectorFn any,
+	options TraverseOptions) Query {
+	childrenSelectorGenericFunc, err := newGenericFunc(
+		"TraverseNodesT", "childrenSelectorFn", childrenSelectorFn,
+		simpleParamValidator(newElemTypeSlice(new(genericType)), newElemTypeSlice(new(Query))),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	childrenSelectorFunc := func(node any) Query {
+		return childrenSelectorGenericFunc.Call(node).(Query)
+	}
+
+	return TraverseNodes(root, childrenSelectorFunc, options)
+}
+
+// traversal holds the state of a single iteration of Traverse or
+// TraverseNodes.
+type traversal struct {
+	childrenSelector func(any) Query
+	options          TraverseOptions
+	withPath         bool
+	yield            func(TreeNode) bool
+
+	visited map[any]struct{}
+	path    []any
+}
+
+func (t *traversal) run(root any) {
+	if t.options.DetectCycles {
+		t.visited = make(map[any]struct{})
+	}
+
+	if !t.visit(root) {
+		return
+	}
+
+	switch t.options.Order {
+	case BreadthFirst:
+		t.breadthFirst(root)
+	default:
+		t.depthFirst(root, 0)
+	}
+}
+
+// visit reports whether node has to be traversed, and marks it as visited if
+// cycles are detected.
+func (t *traversal) visit(node any) bool {
+	if t.visited == nil {
+		return true
+	}
+
+	key := node
+	if t.options.KeySelector != nil {
+		key = t.options.KeySelector(node)
+	}
+
+	if _, seen := t.visited[key]; seen {
+		return false
+	}
+	t.visited[key] = struct{}{}
+	return true
+}
+
+// expand reports whether the children of a node at the given depth have to be
+// traversed.
+func (t *traversal) expand(depth int) bool {
+	return t.options.MaxDepth <= 0 || depth < t.options.MaxDepth
+}
+
+func (t *traversal) node(value any, depth int, path []any) TreeNode {
+	node := TreeNode{Value: value, Depth: depth}
+	if t.withPath {
+		node.Path = make([]any, len(path))
+		copy(node.Path, path)
+	}
+	return node
+}
+
+// depthFirst traverses the subtree of node, which has already been visited.
+// It returns false if the consumer stopped the iteration.
+func (t *traversal) depthFirst(node any, depth int) bool {
+	t.path = append(t.path, node)
+	defer func() {
+		t.path = t.path[:len(t.path)-1]
+	}()
+
+	if t.options.Order == PreOrder && !t.yield(t.node(node, depth, t.path)) {
+		return false
+	}
+
+	if t.expand(depth) {
+		keepGoing := true
+		t.childrenSelector(node).Iterate(func(child any) bool {
+			if t.visit(child) {
+				keepGoing = t.depthFirst(child, depth+1)
+			}
+			return keepGoing
+		})
+
+		if !keepGoing {
+			return false
+		}
+	}
+
+	if t.options.Order == PostOrder {
+		return t.yield(t.node(node, depth, t.path))
+	}
+
+	return true
+}
+
+func (t *traversal) breadthFirst(root any) {
+	type entry struct {
+		value any
+		depth int
+		path  []any
+	}
+
+	queue := []entry{{value: root, path: []any{root}}}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue[0] = entry{}
+		queue = queue[1:]
+
+		if !t.yield(t.node(current.value, current.depth, current.path)) {
+			return
+		}
+
+		if !t.expand(current.depth) {
+			continue
+		}
+
+		t.childrenSelector(current.value).Iterate(func(child any) bool {
+			if !t.visit(child) {
+				return true
+			}
+
+			next := entry{value: child, depth: current.depth + 1}
+			if t.withPath {
+				next.path = append(current.path[:len(current.path):len(current.path)], child)
+			}
+			queue = append(queue, next)
+			return true
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package linq
+
+import (
+	"fmt"
+	"testing"
+)
+
+type treeItem struct {
+	name     string
+	children []*treeItem
+}
+
+func (n *treeItem) childrenQuery() Query {
+	return FromSlice(n.children)
+}
+
+// newTestTree returns the following tree:
+//
+//	a
+//	├── b
+//	│   ├── d
+//	│   └── e
+//	└── c
+//	    └── f
+func newTestTree() *treeItem {
+	return &treeItem{name: "a", children: []*treeItem{
+		{name: "b", children: []*treeItem{{name: "d"}, {name: "e"}}},
+		{name: "c", children: []*treeItem{{name: "f"}}},
+	}}
+}
+
+func treeItemName(i any) any {
+	return i.(*treeItem).name
+}
+
+func TestTraverse(t *testing.T) {
+	tests := []struct {
+		order  TraversalOrder
+		output []any
+	}{
+		{PreOrder, []any{"a", "b", "d", "e", "c", "f"}},
+		{PostOrder, []any{"d", "e", "b", "f", "c", "a"}},
+		{BreadthFirst, []any{"a", "b", "c", "d", "e", "f"}},
+	}
+
+	for _, test := range tests {
+		q := Traverse(newTestTree(), func(i any) Query {
+			return i.(*treeItem).childrenQuery()
+		}, test.order).Select(treeItemName)
+
+		if !testQueryIteration(q, test.output) {
+			t.Errorf("Traverse(%v)=%v expected %v", test.order, toSlice(q), test.output)
+		}
+	}
+}
+
+func TestTraverse_Lazy(t *testing.T) {
+	calls := 0
+	q := Traverse(1, func(i any) Query {
+		calls++
+		return From([]int{i.(int) * 2, i.(int)*2 + 1})
+	}, PreOrder)
+
+	want := []any{1, 2, 4, 8, 16}
+	if !testQueryIteration(q.Take(5), want) {
+		t.Errorf("Traverse().Take(5)=%v expected %v", toSlice(q.Take(5)), want)
+	}
+	// Take reads one element past the last one it yields.
+	if calls != 5 {
+		t.Errorf("Traverse().Take(5) requested children %d times expected 5", calls)
+	}
+
+	want = []any{1, 2, 3, 4, 5, 6, 7}
+	if q := Traverse(1, func(i any) Query {
+		return From([]int{i.(int) * 2, i.(int)*2 + 1})
+	}, BreadthFirst).Take(7); !testQueryIteration(q, want) {
+		t.Errorf("Traverse(BreadthFirst).Take(7)=%v expected %v", toSlice(q), want)
+	}
+}
+
+func TestTraverseT_PanicWhenChildrenSelectorFnIsInvalid(t *testing.T) {
+	mustPanicWithError(t, "TraverseT: parameter [childrenSelectorFn] has a invalid function signature. Expected: 'func(T)linq.Query', actual: 'func(int)int'", func() {
+		TraverseT(1, func(item int) int { return item + 2 }, PreOrder)
+	})
+}
+
+func TestTraverseNodes(t *testing.T) {
+	children := func(i any) Query {
+		return i.(*treeItem).childrenQuery()
+	}
+
+	tests := []struct {
+		options TraverseOptions
+		output  []any
+	}{
+		{TraverseOptions{}, []any{
+			"a:0:[a]", "b:1:[a b]", "d:2:[a b d]", "e:2:[a b e]", "c:1:[a c]", "f:2:[a c f]",
+		}},
+		{TraverseOptions{Order: BreadthFirst, MaxDepth: 1}, []any{
+			"a:0:[a]", "b:1:[a b]", "c:1:[a c]",
+		}},
+		{TraverseOptions{Order: PostOrder, MaxDepth: 1}, []any{
+			"b:1:[a b]", "c:1:[a c]", "a:0:[a]",
+		}},
+		{TraverseOptions{Order: BreadthFirst}, []any{
+			"a:0:[a]", "b:1:[a b]", "c:1:[a c]", "d:2:[a b d]", "e:2:[a b e]", "f:2:[a c f]",
+		}},
+	}
+
+	for _, test := range tests {
+		q := TraverseNodes(newTestTree(), children, test.options).Select(func(i any) any {
+			node := i.(TreeNode)
+			path := From(node.Path).Select(treeItemName).Results()
+			return fmt.Sprintf("%v:%d:%v", treeItemName(node.Value), node.Depth, path)
+		})
+
+		if !testQueryIteration(q, test.output) {
+			t.Errorf("TraverseNodes(%+v)=%v expected %v", test.options, toSlice(q), test.output)
+		}
+	}
+}
+
+func TestTraverseNodes_DetectCycles(t *testing.T) {
+	graph := map[string][]string{
+		"a": {"b", "c"},
+		"b": {"c", "a"},
+		"c": {"a", "d"},
+		"d": {"d"},
+	}
+	children := func(i any) Query {
+		return FromSlice(graph[i.(string)])
+	}
+
+	tests := []struct {
+		order  TraversalOrder
+		output []any
+	}{
+		{PreOrder, []any{"a", "b", "c", "d"}},
+		{PostOrder, []any{"d", "c", "b", "a"}},
+		{BreadthFirst, []any{"a", "b", "c", "d"}},
+	}
+
+	for _, test := range tests {
+		q := TraverseNodes("a", children, TraverseOptions{
+			Order:        test.order,
+			DetectCycles: true,
+		}).Select(func(i any) any {
+			return i.(TreeNode).Value
+		})
+
+		if !testQueryIteration(q, test.output) {
+			t.Errorf("TraverseNodes(%v)=%v expected %v", test.order, toSlice(q), test.output)
+		}
+	}
+}
+
+func TestTraverseNodes_KeySelector(t *testing.T) {
+	type node struct {
+		id    int
+		links []int
+	}
+	nodes := map[int]node{
+		1: {1, []int{2, 3}},
+		2: {2, []int{1}},
+		3: {3, []int{2}},
+	}
+
+	q := TraverseNodes(nodes[1], func(i any) Query {
+		return From(i.(node).links).Select(func(id any) any {
+			return nodes[id.(int)]
+		})
+	}, TraverseOptions{
+		DetectCycles: true,
+		KeySelector:  func(i any) any { return i.(node).id },
+	}).Select(func(i any) any {
+		return i.(TreeNode).Value.(node).id
+	})
+
+	if want := []any{1, 2, 3}; !testQueryIteration(q, want) {
+		t.Errorf("TraverseNodes()=%v expected %v", toSlice(q), want)
+	}
+}
+
+func TestTraverseNodes_PathIsNotShared(t *testing.T) {
+	nodes := TraverseNodes(newTestTree(), func(i any) Query {
+		return i.(*treeItem).childrenQuery()
+	}, TraverseOptions{}).Results()
+
+	nodes[2].(TreeNode).Path[1] = nil
+
+	if got := nodes[1].(TreeNode).Path[1]; got == nil {
+		t.Errorf("TraverseNodes() paths share memory")
+	}
+}
+
+func TestTraverseNodesT_PanicWhenChildrenSelectorFnIsInvalid(t *testing.T) {
+	mustPanicWithError(t, "TraverseNodesT: parameter [childrenSelectorFn] has a invalid function signature. Expected: 'func(T)linq.Query', actual: 'func(int)int'", func() {
+		TraverseNodesT(1, func(item int) int { return item + 2 }, TraverseOptions{})
+	})
+}
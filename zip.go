@@ -61,3 +61,155 @@ func (q Query) ZipT(q2 Query,
 
 	return q.Zip(q2, resultSelectorFunc)
 }
+
+// Zip3 applies a specified function to the corresponding elements of three
+// collections, producing a collection of the results.
+//
+// Like Zip, the method combines elements until it reaches the end of the
+// shortest of the collections.
+func (q Query) Zip3(q2 Query, q3 Query,
+	resultSelector func(any, any, any) any) Query {
+
+	return Query{
+		Iterate: func(yield func(any) bool) {
+			ZipN(q, q2, q3).Iterate(func(item any) bool {
+				tuple := item.([]any)
+				return yield(resultSelector(tuple[0], tuple[1], tuple[2]))
+			})
+		},
+	}
+}
+
+// Zip3T is the typed version of Zip3.
+//
+//   - resultSelectorFn is of type "func(TFirst,TSecond,TThird)TResult"
+//
+// NOTE: Zip3 has better performance than Zip3T.
+func (q Query) Zip3T(q2 Query, q3 Query,
+	resultSelectorFn any) Query {
+	resultSelectorGenericFunc, err := newGenericFunc(
+		"Zip3T", "resultSelectorFn", resultSelectorFn,
+		simpleParamValidator(newElemTypeSlice(new(genericType), new(genericType), new(genericType)), newElemTypeSlice(new(genericType))),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	resultSelectorFunc := func(item1 any, item2 any, item3 any) any {
+		return resultSelectorGenericFunc.Call(item1, item2, item3)
+	}
+
+	return q.Zip3(q2, q3, resultSelectorFunc)
+}
+
+// ZipN combines the corresponding elements of any number of collections into
+// []any tuples. The i-th tuple holds the i-th element of every collection, in
+// the order the collections were passed. Every yielded tuple is a new slice
+// that can be safely retained by the caller.
+//
+// Like Zip, the method combines elements until it reaches the end of the
+// shortest of the collections. ZipN returns an empty collection if no
+// collections are passed.
+func ZipN(queries ...Query) Query {
+	return Query{
+		Iterate: func(yield func(any) bool) {
+			if len(queries) == 0 {
+				return
+			}
+
+			nexts := make([]func() (any, bool), len(queries))
+			for i, q := range queries {
+				next, stop := iter.Pull(q.Iterate)
+				defer stop()
+				nexts[i] = next
+			}
+
+			for {
+				tuple := make([]any, len(nexts))
+				for i, next := range nexts {
+					item, ok := next()
+					if !ok {
+						return
+					}
+					tuple[i] = item
+				}
+
+				if !yield(tuple) {
+					return
+				}
+			}
+		},
+	}
+}
+
+// ZipLongest applies a specified function to the corresponding elements of two
+// collections, producing a collection of the results.
+//
+// Unlike Zip, ZipLongest continues until both collections are exhausted. Once
+// the shorter collection reaches its end, fillValue is passed to
+// resultSelector in place of its missing elements.
+func (q Query) ZipLongest(q2 Query, fillValue any,
+	resultSelector func(any, any) any) Query {
+
+	return Query{
+		Iterate: func(yield func(any) bool) {
+			next1, stop1 := iter.Pull(q.Iterate)
+			defer stop1()
+
+			next2, stop2 := iter.Pull(q2.Iterate)
+			defer stop2()
+
+			ok1, ok2 := true, true
+			for {
+				item1, item2 := fillValue, fillValue
+				if ok1 {
+					if item, ok := next1(); ok {
+						item1 = item
+					} else {
+						ok1 = false
+					}
+				}
+				if ok2 {
+					if item, ok := next2(); ok {
+						item2 = item
+					} else {
+						ok2 = false
+					}
+				}
+
+				if !ok1 && !ok2 {
+					return
+				}
+
+				result := resultSelector(item1, item2)
+				if !yield(result) {
+					return
+				}
+			}
+		},
+	}
+}
+
+// ZipLongestT is the typed version of ZipLongest.
+//
+//   - resultSelectorFn is of type "func(TFirst,TSecond)TResult"
+//
+// fillValue has to be assignable to both TFirst and TSecond.
+//
+// NOTE: ZipLongest has better performance than ZipLongestT.
+func (q Query) ZipLongestT(q2 Query, fillValue any,
+	resultSelectorFn any) Query {
+	resultSelectorGenericFunc, err := newGenericFunc(
+		"ZipLongestT", "resultSelectorFn", resultSelectorFn,
+		simpleParamValidator(newElemTypeSlice(new(genericType), new(genericType)), newElemTypeSlice(new(genericType))),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	resultSelectorFunc := func(item1 any, item2 any) any {
+		return resultSelectorGenericFunc.Call(item1, item2)
+	}
+
+	return q.ZipLongest(q2, fillValue, resultSelectorFunc)
+}
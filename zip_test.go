@@ -1,6 +1,9 @@
 package linq
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+)
 
 func TestZip(t *testing.T) {
 	input1 := []int{1, 2, 3}
@@ -24,3 +27,73 @@ func TestZipT_PanicWhenResultSelectorFnIsInvalid(t *testing.T) {
 		})
 	})
 }
+
+func TestZip3(t *testing.T) {
+	input1 := []int{1, 2, 3}
+	input2 := []int{10, 20, 30, 40}
+	input3 := []int{100, 200, 300}
+	want := []any{111, 222, 333}
+
+	if q := From(input1).Zip3(From(input2), From(input3), func(i, j, k any) any {
+		return i.(int) + j.(int) + k.(int)
+	}); !testQueryIteration(q, want) {
+		t.Errorf("From(%v).Zip3(%v, %v)=%v expected %v", input1, input2, input3, toSlice(q), want)
+	}
+}
+
+func TestZip3T_PanicWhenResultSelectorFnIsInvalid(t *testing.T) {
+	mustPanicWithError(t, "Zip3T: parameter [resultSelectorFn] has a invalid function signature. Expected: 'func(T,T,T)T', actual: 'func(int,int)int'", func() {
+		From([]int{1}).Zip3T(From([]int{2}), From([]int{3}), func(i, j int) int {
+			return i + j
+		})
+	})
+}
+
+func TestZipN(t *testing.T) {
+	tests := []struct {
+		input []Query
+		want  []any
+	}{
+		{nil, nil},
+		{[]Query{From([]int{1, 2})}, []any{[]any{1}, []any{2}}},
+		{[]Query{From([]int{1, 2, 3}), From("ab"), From([]bool{true, false, true})}, []any{
+			[]any{1, 'a', true},
+			[]any{2, 'b', false},
+		}},
+		{[]Query{From([]int{1, 2, 3}), From([]int{})}, nil},
+	}
+
+	for _, test := range tests {
+		if got := ZipN(test.input...).Results(); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("ZipN()=%v expected %v", got, test.want)
+		}
+	}
+}
+
+func TestZipLongest(t *testing.T) {
+	tests := []struct {
+		input1 []int
+		input2 []int
+		want   []any
+	}{
+		{[]int{1, 2, 3}, []int{10, 20}, []any{11, 22, 3}},
+		{[]int{1}, []int{10, 20, 30}, []any{11, 20, 30}},
+		{[]int{}, []int{}, nil},
+	}
+
+	for _, test := range tests {
+		if q := From(test.input1).ZipLongest(From(test.input2), 0, func(i, j any) any {
+			return i.(int) + j.(int)
+		}); !testQueryIteration(q, test.want) {
+			t.Errorf("From(%v).ZipLongest(%v)=%v expected %v", test.input1, test.input2, toSlice(q), test.want)
+		}
+	}
+}
+
+func TestZipLongestT_PanicWhenResultSelectorFnIsInvalid(t *testing.T) {
+	mustPanicWithError(t, "ZipLongestT: parameter [resultSelectorFn] has a invalid function signature. Expected: 'func(T,T)T', actual: 'func(int)int'", func() {
+		From([]int{1}).ZipLongestT(From([]int{2}), 0, func(i int) int {
+			return i
+		})
+	})
+}